@@ -0,0 +1,250 @@
+package genetic
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Operator is a named combination of variation operators, which an OperatorSelector may choose
+// to breed children. A nil Crossover or Mutation falls back to that of the Population.
+type Operator[T any] struct {
+	// Name identifies the operator in Individual.Operators and OperatorSelector.Weights.
+	Name string
+
+	// Crossover recombines the two parents.
+	Crossover CrossoverFunc[T]
+
+	// Mutation mutates each child after crossover.
+	Mutation MutationFunc[T]
+}
+
+type adaptationMethod int
+
+const (
+	probabilityMatching adaptationMethod = iota
+	adaptivePursuit
+)
+
+// operatorCredit is a pending reward for an operator, awaiting the fitness of a child it produced.
+type operatorCredit struct {
+	operator      int
+	parentFitness int
+}
+
+// OperatorSelector adaptively chooses between several Operators when breeding, learning which
+// produce the most improvement. Each time an operator produces a child, it is credited with the
+// child's fitness gain over the fitter of its two parents, or zero if the child is no fitter. The
+// selector keeps a running estimate of each operator's quality from these rewards, and chooses
+// operators with probabilities derived from their estimated qualities.
+//
+// Assign an OperatorSelector to Population.Operators to use it. It is safe for concurrent use.
+type OperatorSelector[T any] struct {
+	operators      []Operator[T]
+	method         adaptationMethod
+	minProbability float64
+	adaptationRate float64
+	learningRate   float64
+
+	mutex         sync.Mutex
+	qualities     []float64
+	probabilities []float64
+	uses          []int
+	pending       map[uint64]operatorCredit
+}
+
+func newOperatorSelector[T any](
+	method adaptationMethod,
+	minProbability, adaptationRate, learningRate float64,
+	operators []Operator[T],
+) *OperatorSelector[T] {
+	if len(operators) < 2 {
+		panic("expected to receive at least 2 operators")
+	} else if minProbability < 0 || minProbability*float64(len(operators)) >= 1 {
+		panic(fmt.Sprintf("invalid minimum probability %f for %d operators", minProbability, len(operators)))
+	} else if adaptationRate <= 0 || adaptationRate > 1 {
+		panic("invalid adaptation rate, must be greater than 0 and at most 1")
+	}
+
+	names := make(map[string]bool)
+	for _, operator := range operators {
+		if names[operator.Name] {
+			panic(fmt.Sprintf("duplicate operator name: %q", operator.Name))
+		}
+		names[operator.Name] = true
+	}
+
+	selector := &OperatorSelector[T]{
+		operators:      operators,
+		method:         method,
+		minProbability: minProbability,
+		adaptationRate: adaptationRate,
+		learningRate:   learningRate,
+		qualities:      make([]float64, len(operators)),
+		probabilities:  make([]float64, len(operators)),
+		uses:           make([]int, len(operators)),
+		pending:        make(map[uint64]operatorCredit),
+	}
+	for i := range selector.probabilities {
+		selector.probabilities[i] = 1 / float64(len(operators))
+	}
+
+	return selector
+}
+
+// NewProbabilityMatching returns an OperatorSelector which chooses each operator with probability
+// proportional to its estimated quality, but never less than minProbability. adaptationRate, in
+// (0, 1], controls how quickly quality estimates follow recent rewards.
+func NewProbabilityMatching[T any](minProbability, adaptationRate float64, operators ...Operator[T]) *OperatorSelector[T] {
+	return newOperatorSelector(probabilityMatching, minProbability, adaptationRate, 0, operators)
+}
+
+// NewAdaptivePursuit returns an OperatorSelector which pursues the operator with the best estimated
+// quality: after each reward, the probability of the best operator is moved towards
+// 1 - (K-1)*minProbability, and the probabilities of the other K-1 operators towards minProbability,
+// at the given learningRate. If several operators are tied for the best quality, they share that
+// probability equally. adaptationRate, in (0, 1], controls how quickly quality estimates follow
+// recent rewards. Adaptive pursuit reacts more decisively than probability matching when one
+// operator is clearly best.
+func NewAdaptivePursuit[T any](minProbability, adaptationRate, learningRate float64, operators ...Operator[T]) *OperatorSelector[T] {
+	if learningRate <= 0 || learningRate > 1 {
+		panic("invalid learning rate, must be greater than 0 and at most 1")
+	}
+	return newOperatorSelector(adaptivePursuit, minProbability, adaptationRate, learningRate, operators)
+}
+
+// choose picks an operator at random according to the current probabilities.
+func (selector *OperatorSelector[T]) choose() int {
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	// Probabilities may not sum exactly to 1 due to rounding,
+	// so the last operator takes any remainder.
+	i := 0
+	position := randFloat()
+	for ; i < len(selector.probabilities)-1; i++ {
+		position -= selector.probabilities[i]
+		if position <= 0 {
+			break
+		}
+	}
+
+	selector.uses[i]++
+	return i
+}
+
+// expect registers that the child with the given ID was produced by the given operator.
+func (selector *OperatorSelector[T]) expect(childID uint64, operator, parentFitness int) {
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	selector.pending[childID] = operatorCredit{operator, parentFitness}
+}
+
+// discard forgets the pending credit for the given individuals, which will never be evaluated.
+func (selector *OperatorSelector[T]) discard(individuals []Individual) {
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	for _, individual := range individuals {
+		delete(selector.pending, individual.ID)
+	}
+}
+
+// credit rewards the operators which produced any of the given individuals,
+// now that their fitnesses are known.
+func (selector *OperatorSelector[T]) credit(individuals []Individual, fitnesses []int) {
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	for i, individual := range individuals {
+		credit, ok := selector.pending[individual.ID]
+		if !ok {
+			continue
+		}
+		delete(selector.pending, individual.ID)
+
+		reward := 0.0
+		if fitnesses[i] > credit.parentFitness {
+			reward = float64(fitnesses[i] - credit.parentFitness)
+		}
+		selector.update(credit.operator, reward)
+	}
+}
+
+// update adjusts the quality estimate of the given operator, and then recomputes the probabilities.
+func (selector *OperatorSelector[T]) update(operator int, reward float64) {
+	selector.qualities[operator] += selector.adaptationRate * (reward - selector.qualities[operator])
+
+	k := float64(len(selector.operators))
+	switch selector.method {
+	case probabilityMatching:
+		total := 0.0
+		for _, quality := range selector.qualities {
+			total += quality
+		}
+		for i, quality := range selector.qualities {
+			share := 1 / k
+			if total > 0 {
+				share = quality / total
+			}
+			selector.probabilities[i] = selector.minProbability + (1-k*selector.minProbability)*share
+		}
+
+	case adaptivePursuit:
+		bestQuality := selector.qualities[0]
+		for _, quality := range selector.qualities[1:] {
+			if quality > bestQuality {
+				bestQuality = quality
+			}
+		}
+		bestCount := 0
+		for _, quality := range selector.qualities {
+			if quality == bestQuality {
+				bestCount++
+			}
+		}
+
+		// Operators tied for the best quality share the pursued probability equally,
+		// so that no operator is favoured before it has earned a better reward.
+		bestProbability := selector.minProbability + (1-k*selector.minProbability)/float64(bestCount)
+		for i, quality := range selector.qualities {
+			target := selector.minProbability
+			if quality == bestQuality {
+				target = bestProbability
+			}
+			selector.probabilities[i] += selector.learningRate * (target - selector.probabilities[i])
+		}
+	}
+}
+
+// Weights returns the current probability of choosing each operator, keyed by operator name.
+func (selector *OperatorSelector[T]) Weights() map[string]float64 {
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	weights := make(map[string]float64, len(selector.operators))
+	for i, operator := range selector.operators {
+		weights[operator.Name] = selector.probabilities[i]
+	}
+	return weights
+}
+
+// Uses returns the number of times each operator has been chosen, keyed by operator name.
+func (selector *OperatorSelector[T]) Uses() map[string]int {
+	selector.mutex.Lock()
+	defer selector.mutex.Unlock()
+
+	uses := make(map[string]int, len(selector.operators))
+	for i, operator := range selector.operators {
+		uses[operator.Name] = selector.uses[i]
+	}
+	return uses
+}
+
+// creditOperators rewards the population's OperatorSelector, if any, for the
+// given individuals, whose fitnesses have just been computed.
+func (population *Population[T]) creditOperators(individuals []Individual, fitnesses []int) {
+	if population.Operators != nil {
+		population.Operators.credit(individuals, fitnesses)
+	}
+}
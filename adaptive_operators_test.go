@@ -0,0 +1,72 @@
+package genetic_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func testOperatorSelector(t *testing.T, selector *genetic.OperatorSelector[[]int]) {
+	genomes := make([][]int, 40)
+	for i := range genomes {
+		genomes[i] = []int{rand.Intn(100)}
+	}
+	population := newTestPopulation(geneFitness, genomes...)
+	population.Operators = selector
+
+	for i := 0; i < 30; i++ {
+		population.EvolveOnce(2)
+	}
+
+	weights := selector.Weights()
+	if weights["improve"] <= 0.6 {
+		t.Errorf("expected improving operator to be favoured; got weights %v", weights)
+	}
+	if weights["worsen"] < 0.05 {
+		t.Errorf("expected worsening operator to keep its minimum probability; got weights %v", weights)
+	}
+
+	uses := selector.Uses()
+	if uses["improve"]+uses["worsen"] != 30*20 {
+		t.Errorf("expected one operator use per mating pair; got %v", uses)
+	}
+
+	for _, individual := range population.Individuals() {
+		if individual.BirthGeneration > 0 && len(individual.Operators) != 1 {
+			t.Errorf("expected bred individual to record the chosen operator; got %v", individual.Operators)
+		}
+	}
+}
+
+var testOperators = []genetic.Operator[[]int]{
+	{Name: "improve", Mutation: func(genome []int) { genome[0] += 1 + rand.Intn(5) }},
+	{Name: "worsen", Mutation: func(genome []int) { genome[0] -= 1 + rand.Intn(5) }},
+}
+
+func TestProbabilityMatching(t *testing.T) {
+	testOperatorSelector(t, genetic.NewProbabilityMatching(0.05, 0.3, testOperators...))
+}
+
+func TestAdaptivePursuit(t *testing.T) {
+	testOperatorSelector(t, genetic.NewAdaptivePursuit(0.05, 0.3, 0.1, testOperators...))
+}
+
+func TestAdaptivePursuitTiedQualities(t *testing.T) {
+	// Neither operator ever improves on its parents, so their qualities stay tied.
+	selector := genetic.NewAdaptivePursuit(0.05, 0.3, 0.1,
+		genetic.Operator[[]int]{Name: "first"},
+		genetic.Operator[[]int]{Name: "second"},
+	)
+	population := newTestPopulation(constantFitness, countingGenomes(20)...)
+	population.Operators = selector
+
+	for i := 0; i < 10; i++ {
+		population.EvolveOnce(2)
+	}
+
+	weights := selector.Weights()
+	if weights["first"] != 0.5 || weights["second"] != 0.5 {
+		t.Errorf("expected tied operators to keep equal probabilities; got weights %v", weights)
+	}
+}
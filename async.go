@@ -0,0 +1,155 @@
+package genetic
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AsyncStats summarizes a run of asynchronous evolution.
+type AsyncStats struct {
+	// Evaluations is the number of genomes whose fitness was computed.
+	Evaluations int
+
+	// Duration is the wall-clock time the run took.
+	Duration time.Duration
+}
+
+// EvaluationsPerSecond returns the throughput of the run, in fitness evaluations per second.
+func (stats AsyncStats) EvaluationsPerSecond() float64 {
+	if stats.Duration <= 0 {
+		return 0
+	}
+	return float64(stats.Evaluations) / stats.Duration.Seconds()
+}
+
+// EvolveAsync evolves the population asynchronously in steady-state mode, using the given number
+// of worker goroutines. Each worker repeatedly breeds two children from a pair of mates chosen as
+// in StepSteadyState, evaluates them without holding any lock, and then inserts them
+// into the population. Slow evaluations therefore never hold up the other workers, as they would
+// between the generations of EvolveOnce.
+//
+// Evolution stops once a genome is produced which meets the given fitnessThreshold, once
+// maxEvaluations genomes have been evaluated, or once ctx is cancelled, whichever comes first.
+// EvolveAsync blocks until every worker has finished, and then returns statistics about the run.
+//
+// The population's FitnessFunc is called concurrently, with two children at a time, so it must
+// be safe for concurrent use, and should not depend on the rest of the population. Children are
+// inserted by the population's ReplacementFunc, or ReplaceWorst if it has none. Since the
+// population may have changed while children were evaluated, the ReplacementPool passed to the
+// ReplacementFunc has MatingPairs only if both parents of the children are still members of the
+// population, and both children are being inserted. Otherwise, crowding strategies such as
+// DeterministicCrowding match the children against their most similar members instead of their
+// parents. The population's Observers are called after every
+// insertion, while the other workers are blocked from breeding and inserting, so they should
+// return quickly.
+//
+// If any of the population's functions panics in a worker, the other workers stop once their
+// current children are inserted, and EvolveAsync then panics with the same value.
+func (population *Population[T]) EvolveAsync(ctx context.Context, workers, fitnessThreshold, maxEvaluations int) AsyncStats {
+	if workers < 1 {
+		panic("cannot evolve asynchronously with fewer than 1 worker")
+	}
+
+	start := time.Now()
+	started := 0
+	completed := 0
+	var panicked any
+
+	// breed reserves evaluations and breeds children, or returns nil if evolution should stop.
+	breed := func() ([]T, []Individual) {
+		population.mutex.Lock()
+		defer population.mutex.Unlock()
+
+		if panicked != nil || ctx.Err() != nil || started >= maxEvaluations || population.fitnesses[0] >= fitnessThreshold {
+			return nil, nil
+		}
+
+		childGenomes, childIndividuals, _ := population.breedSteadyState()
+		if remaining := maxEvaluations - started; len(childGenomes) > remaining {
+			if population.Operators != nil {
+				population.Operators.discard(childIndividuals[remaining:])
+			}
+			childGenomes = childGenomes[:remaining]
+			childIndividuals = childIndividuals[:remaining]
+		}
+		started += len(childGenomes)
+		return childGenomes, childIndividuals
+	}
+
+	insert := func(childGenomes []T, childFitnesses []int, childIndividuals []Individual) {
+		population.mutex.Lock()
+		defer population.mutex.Unlock()
+
+		population.creditOperators(childIndividuals, childFitnesses)
+		population.insertSteadyState(childGenomes, childFitnesses, childIndividuals, population.currentMatingPairs(childIndividuals))
+		completed += len(childGenomes)
+		population.checkRestart()
+		population.notifyObservers()
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				// A panic cannot be recovered by the caller from a worker's goroutine, so the
+				// first is kept to be raised again once every worker has stopped.
+				if r := recover(); r != nil {
+					population.mutex.Lock()
+					defer population.mutex.Unlock()
+					if panicked == nil {
+						panicked = r
+						population.logPanicValue(r)
+					}
+				}
+			}()
+
+			for {
+				childGenomes, childIndividuals := breed()
+				if childGenomes == nil {
+					return
+				}
+
+				childFitnesses := unknownFitnesses(len(childGenomes))
+				population.evaluate(childGenomes, childFitnesses, 0)
+				insert(childGenomes, childFitnesses, childIndividuals)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+
+	return AsyncStats{
+		Evaluations: completed,
+		Duration:    time.Since(start),
+	}
+}
+
+// currentMatingPairs returns the mating pair of the given sibling children as indexes into
+// the current population, or nil if either parent is no longer a member, or if fewer than
+// two children are given.
+func (population *Population[T]) currentMatingPairs(childIndividuals []Individual) [][2]int {
+	if len(childIndividuals) != 2 || len(childIndividuals[0].Parents) != 2 {
+		return nil
+	}
+
+	parents := childIndividuals[0].Parents
+	matingPair := [2]int{-1, -1}
+	for i, individual := range population.individuals {
+		for p, parentID := range parents {
+			if individual.ID == parentID && matingPair[p] < 0 {
+				matingPair[p] = i
+			}
+		}
+	}
+
+	if matingPair[0] < 0 || matingPair[1] < 0 {
+		return nil
+	}
+	return [][2]int{matingPair}
+}
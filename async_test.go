@@ -0,0 +1,91 @@
+package genetic_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kklash/genetic"
+)
+
+func TestEvolveAsync(t *testing.T) {
+	var evaluations int64
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(func(genome []int) int {
+		atomic.AddInt64(&evaluations, 1)
+		time.Sleep(time.Duration(rand.Intn(200)) * time.Microsecond)
+		return twinPeaksFitness(genome)
+	}))
+	atomic.StoreInt64(&evaluations, 0)
+
+	maxEvaluations := 301
+	stats := population.EvolveAsync(context.Background(), 8, 1001, maxEvaluations)
+
+	if stats.Evaluations != maxEvaluations {
+		t.Errorf("expected %d evaluations; got %d", maxEvaluations, stats.Evaluations)
+	}
+	if n := atomic.LoadInt64(&evaluations); n != int64(maxEvaluations) {
+		t.Errorf("expected fitness to be computed %d times; got %d", maxEvaluations, n)
+	}
+	if stats.EvaluationsPerSecond() <= 0 {
+		t.Errorf("expected positive throughput; got %f", stats.EvaluationsPerSecond())
+	}
+
+	_, bestFitness := population.Best()
+	if bestFitness < 950 {
+		t.Errorf("expected asynchronous evolution to approach an optimum; best fitness %d", bestFitness)
+	}
+}
+
+func TestEvolveAsyncCancel(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stats := population.EvolveAsync(ctx, 4, 1001, 1000)
+	if stats.Evaluations != 0 {
+		t.Errorf("expected no evaluations after cancellation; got %d", stats.Evaluations)
+	}
+}
+
+func TestEvolveAsyncCrowding(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(func(genome []int) int { return genome[0] }))
+	population.Replacement = genetic.DeterministicCrowding(lineDistance)
+	_, initialFitness := population.Best()
+
+	population.EvolveAsync(context.Background(), 4, 1<<30, 2000)
+
+	if _, bestFitness := population.Best(); bestFitness <= initialFitness {
+		t.Errorf("expected crowding to insert fitter children during asynchronous evolution; best fitness stayed at %d", bestFitness)
+	}
+}
+
+func TestEvolveAsyncPanic(t *testing.T) {
+	var failing atomic.Bool
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(func(genome []int) int {
+		if failing.Load() {
+			panic("fitness failed")
+		}
+		return twinPeaksFitness(genome)
+	}))
+	failing.Store(true)
+
+	var output bytes.Buffer
+	population.Logger = slog.New(slog.NewJSONHandler(&output, nil))
+
+	defer func() {
+		if r := recover(); r != "fitness failed" {
+			t.Errorf("expected worker panic to be raised by EvolveAsync; got %v", r)
+		}
+
+		events := decodeLogEvents(t, &output)
+		if len(events) != 1 || events[0]["error"] != "fitness failed" {
+			t.Errorf("expected the panic to be logged once; got %v", events)
+		}
+	}()
+	population.EvolveAsync(context.Background(), 4, 2000, 2000)
+}
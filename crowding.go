@@ -0,0 +1,127 @@
+package genetic
+
+// crowdingReplacement returns a ReplacementFunc in which each child competes only against the
+// parent it most resembles. The two children of each mating pair are matched to the two parents
+// such that the total distance between matched child and parent is minimized. Any children not
+// accounted for by the pool's MatingPairs instead compete against the most similar member of the
+// population. childWins decides whether a child displaces the current occupant of a slot.
+func crowdingReplacement[T any](distance DistanceFunc[T], childWins func(childFitness, parentFitness int) bool) ReplacementFunc[T] {
+	if distance == nil {
+		panic("expected to receive DistanceFunc")
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		parentCount := len(pool.Parents)
+
+		survivors := make([]int, parentCount)
+		for i := range survivors {
+			survivors[i] = i
+		}
+
+		compete := func(slot, child int) {
+			if childWins(pool.Fitness(child), pool.Fitness(survivors[slot])) {
+				survivors[slot] = child
+			}
+		}
+
+		for k, matingPair := range pool.MatingPairs {
+			parent1, parent2 := matingPair[0], matingPair[1]
+			child1, child2 := parentCount+2*k, parentCount+2*k+1
+
+			straight := distance(pool.Genome(parent1), pool.Genome(child1)) + distance(pool.Genome(parent2), pool.Genome(child2))
+			crossed := distance(pool.Genome(parent1), pool.Genome(child2)) + distance(pool.Genome(parent2), pool.Genome(child1))
+			if crossed < straight {
+				child1, child2 = child2, child1
+			}
+
+			compete(parent1, child1)
+			compete(parent2, child2)
+		}
+
+		for child := parentCount + 2*len(pool.MatingPairs); child < pool.Len(); child++ {
+			nearestSlot := 0
+			nearestDistance := distance(pool.Genome(child), pool.Genome(survivors[0]))
+			for slot := 1; slot < parentCount; slot++ {
+				if d := distance(pool.Genome(child), pool.Genome(survivors[slot])); d < nearestDistance {
+					nearestSlot, nearestDistance = slot, d
+				}
+			}
+			compete(nearestSlot, child)
+		}
+
+		return survivors
+	}
+}
+
+// DeterministicCrowding returns a ReplacementFunc which preserves diversity by making each child
+// compete only against its most similar parent, as measured by the given distance function. A child
+// replaces its parent in the next generation only if the child is strictly fitter. Since children
+// tend to resemble their parents, a genome can only be displaced by a better genome in the same
+// region of the search space, allowing multiple optima to be maintained without a niche radius.
+//
+// Crowding works best with a SelectionFunc which imposes little selection pressure, such as
+// TournamentSelection with a small pool size.
+func DeterministicCrowding[T any](distance DistanceFunc[T]) ReplacementFunc[T] {
+	return crowdingReplacement(distance, func(childFitness, parentFitness int) bool {
+		return childFitness > parentFitness
+	})
+}
+
+// ProbabilisticCrowding returns a ReplacementFunc similar to DeterministicCrowding, except that
+// each child replaces its most similar parent with probability proportional to their fitnesses,
+// childFitness / (childFitness + parentFitness). Weaker children thus sometimes survive, which
+// maintains more diversity than DeterministicCrowding. Fitnesses must not be negative.
+func ProbabilisticCrowding[T any](distance DistanceFunc[T]) ReplacementFunc[T] {
+	return crowdingReplacement(distance, func(childFitness, parentFitness int) bool {
+		if childFitness < 0 || parentFitness < 0 {
+			panic("cannot use probabilistic crowding with negative fitness")
+		} else if childFitness+parentFitness == 0 {
+			return randFloat() < 0.5
+		}
+		return randFloat() < floatDiv(childFitness, childFitness+parentFitness)
+	})
+}
+
+// RestrictedTournamentReplacement returns a ReplacementFunc which implements restricted tournament
+// selection. For each child, windowSize members of the next generation are sampled at random, and
+// the child competes against whichever of them it most resembles, as measured by the given
+// distance function. The child replaces that member only if the child is strictly fitter.
+func RestrictedTournamentReplacement[T any](distance DistanceFunc[T], windowSize int) ReplacementFunc[T] {
+	if distance == nil {
+		panic("expected to receive DistanceFunc")
+	} else if windowSize < 1 {
+		panic("cannot use restricted tournament replacement with window size less than 1")
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		parentCount := len(pool.Parents)
+		if windowSize > parentCount {
+			panic("cannot select restricted tournament window greater than population size")
+		}
+
+		survivors := make([]int, parentCount)
+		for i := range survivors {
+			survivors[i] = i
+		}
+
+		for c := range pool.Children {
+			child := parentCount + c
+			childGenome := pool.Genome(child)
+
+			nearestSlot := -1
+			nearestDistance := 0.0
+			for _, slot := range randRangeIntsUnique(parentCount, windowSize) {
+				d := distance(childGenome, pool.Genome(survivors[slot]))
+				if nearestSlot < 0 || d < nearestDistance {
+					nearestSlot, nearestDistance = slot, d
+				}
+			}
+
+			if pool.ChildFitnesses[c] > pool.Fitness(survivors[nearestSlot]) {
+				survivors[nearestSlot] = child
+			}
+		}
+
+		return survivors
+	}
+}
@@ -0,0 +1,74 @@
+package genetic_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestDeterministicCrowdingMatchesSimilarParent(t *testing.T) {
+	pool := &genetic.ReplacementPool[[]int]{
+		Parents:         [][]int{{10}, {90}},
+		ParentFitnesses: []int{5, 5},
+		// The first child resembles the second parent, and vice versa.
+		Children:       [][]int{{88}, {12}},
+		ChildFitnesses: []int{9, 1},
+		MatingPairs:    [][2]int{{0, 1}},
+	}
+
+	survivors := genetic.DeterministicCrowding(lineDistance)(pool)
+
+	// Child {88} beats parent {90}; child {12} loses to parent {10}.
+	if fmt.Sprint(survivors) != "[0 2]" {
+		t.Errorf("unexpected survivors\nWanted [0 2]\nGot    %v", survivors)
+	}
+}
+
+func testCrowdingPreservesOptima(t *testing.T, replacement genetic.ReplacementFunc[[]int]) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Replacement = replacement
+	for i := 0; i < 100; i++ {
+		population.EvolveOnce(0)
+	}
+
+	representatives, fitnesses := population.Niches(lineDistance, 20)
+
+	var foundLow, foundHigh bool
+	for i, genome := range representatives {
+		if fitnesses[i] >= 900 {
+			foundLow = foundLow || genome[0] < 50
+			foundHigh = foundHigh || genome[0] >= 50
+		}
+	}
+	if !foundLow || !foundHigh {
+		t.Errorf("expected both optima to survive; got niches %v with fitnesses %v", representatives, fitnesses)
+	}
+}
+
+func TestDeterministicCrowding(t *testing.T) {
+	testCrowdingPreservesOptima(t, genetic.DeterministicCrowding(lineDistance))
+}
+
+func TestProbabilisticCrowding(t *testing.T) {
+	testCrowdingPreservesOptima(t, genetic.ProbabilisticCrowding(lineDistance))
+}
+
+func TestRestrictedTournamentReplacement(t *testing.T) {
+	testCrowdingPreservesOptima(t, genetic.RestrictedTournamentReplacement(lineDistance, 10))
+}
+
+func TestDeterministicCrowdingWithoutMatingPairs(t *testing.T) {
+	replacement := genetic.DeterministicCrowding(lineDistance)
+	survivors := replacement(&genetic.ReplacementPool[[]int]{
+		Parents:         [][]int{{10}, {50}, {90}},
+		ParentFitnesses: []int{1, 1, 1},
+		ParentAges:      []int{0, 0, 0},
+		Children:        [][]int{{52}},
+		ChildFitnesses:  []int{2},
+	})
+
+	if fmt.Sprint(survivors) != "[0 3 2]" {
+		t.Errorf("expected unpaired child to replace its most similar member; got survivors %v", survivors)
+	}
+}
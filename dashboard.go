@@ -0,0 +1,306 @@
+package genetic
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultDashboardHistory is the number of generations of history kept by a Dashboard
+// whose HistoryLimit is zero.
+const DefaultDashboardHistory = 1000
+
+// Controller controls a running evolution. It is implemented by Runner.
+type Controller interface {
+	// Pause suspends evolution after the current generation.
+	Pause()
+
+	// Resume continues a paused evolution.
+	Resume()
+
+	// Stop ends evolution after the current generation.
+	Stop()
+
+	// Paused reports whether evolution is paused.
+	Paused() bool
+}
+
+// DashboardPoint is a point on the fitness-over-time chart served by a Dashboard.
+type DashboardPoint struct {
+	Generation  int      `json:"generation"`
+	BestFitness int      `json:"bestFitness"`
+	MeanFitness float64  `json:"meanFitness"`
+	Diversity   *float64 `json:"diversity,omitempty"`
+}
+
+// DashboardStatus is the current state of a population, as served by a Dashboard.
+type DashboardStatus struct {
+	Generation  int       `json:"generation"`
+	Size        int       `json:"size"`
+	Best        string    `json:"best"`
+	BestFitness int       `json:"bestFitness"`
+	Diversity   *float64  `json:"diversity,omitempty"`
+	Evaluations int       `json:"evaluations"`
+	Updated     time.Time `json:"updated"`
+	Paused      bool      `json:"paused"`
+	Controlled  bool      `json:"controlled"`
+}
+
+// Dashboard is an observer which serves a live view of a running Population over HTTP, so that
+// long experiments can be monitored from a browser. It serves the following routes, relative to
+// where it is mounted:
+//
+//	GET  /         an HTML page charting the population's progress
+//	GET  /status   the current DashboardStatus as JSON
+//	GET  /history  the fitness-over-time chart data as a JSON array of DashboardPoint
+//	POST /pause    pauses evolution
+//	POST /resume   resumes evolution
+//	POST /stop     stops evolution
+//
+// The control routes require a Controller, and respond with 501 Not Implemented otherwise.
+// To stop other websites from controlling the evolution through the operator's browser, they
+// reject requests whose Origin header does not match the dashboard's host with 403 Forbidden.
+// To serve a dashboard under a path prefix, use http.StripPrefix:
+//
+//	dashboard := genetic.NewDashboard(func(genome MyGenome) string { return genome.String() })
+//	population.Observers = append(population.Observers, dashboard.Observe)
+//	http.Handle("/dashboard/", http.StripPrefix("/dashboard", dashboard))
+//
+// Dashboard is safe for concurrent use.
+type Dashboard[T any] struct {
+	mutex   sync.Mutex
+	format  func(T) string
+	status  DashboardStatus
+	history []DashboardPoint
+
+	// Diversity optionally measures the diversity of the population after each generation.
+	// If nil, diversity is not shown.
+	Diversity func(population *Population[T]) float64
+
+	// Controller optionally allows the evolution to be paused, resumed and stopped from the dashboard.
+	Controller Controller
+
+	// HistoryLimit is the number of most recent generations kept for the chart.
+	// Defaults to DefaultDashboardHistory.
+	HistoryLimit int
+}
+
+// NewDashboard initializes a Dashboard which displays genomes using the given format function.
+// If format is nil, genomes are formatted with fmt.Sprint.
+func NewDashboard[T any](format func(T) string) *Dashboard[T] {
+	if format == nil {
+		format = func(genome T) string { return fmt.Sprint(genome) }
+	}
+
+	return &Dashboard[T]{format: format}
+}
+
+// Observe updates the dashboard from a generation of the given population. Its signature
+// matches ObserverFunc.
+func (dashboard *Dashboard[T]) Observe(population *Population[T], stats GenerationStats) {
+	var diversity *float64
+	if dashboard.Diversity != nil {
+		d := dashboard.Diversity(population)
+		if !math.IsNaN(d) {
+			diversity = &d
+		}
+	}
+	best, _ := population.Best()
+	formatted := dashboard.format(best)
+
+	dashboard.mutex.Lock()
+	defer dashboard.mutex.Unlock()
+
+	dashboard.status = DashboardStatus{
+		Generation:  stats.Generation,
+		Size:        stats.Size,
+		Best:        formatted,
+		BestFitness: stats.BestFitness,
+		Diversity:   diversity,
+		Evaluations: stats.TotalEvaluations,
+		Updated:     stats.Time,
+	}
+
+	dashboard.history = append(dashboard.history, DashboardPoint{
+		Generation:  stats.Generation,
+		BestFitness: stats.BestFitness,
+		MeanFitness: stats.MeanFitness,
+		Diversity:   diversity,
+	})
+
+	limit := dashboard.HistoryLimit
+	if limit <= 0 {
+		limit = DefaultDashboardHistory
+	}
+	if excess := len(dashboard.history) - limit; excess > 0 {
+		dashboard.history = append(dashboard.history[:0], dashboard.history[excess:]...)
+	}
+}
+
+// ServeHTTP serves the dashboard.
+func (dashboard *Dashboard[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "", "/":
+		dashboard.serveGet(w, r, func() {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, dashboardHTML)
+		})
+
+	case "/status":
+		dashboard.serveGet(w, r, func() {
+			writeJSON(w, dashboard.Status())
+		})
+
+	case "/history":
+		dashboard.serveGet(w, r, func() {
+			writeJSON(w, dashboard.History())
+		})
+
+	case "/pause":
+		dashboard.serveControl(w, r, Controller.Pause)
+	case "/resume":
+		dashboard.serveControl(w, r, Controller.Resume)
+	case "/stop":
+		dashboard.serveControl(w, r, Controller.Stop)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Status returns the current status of the observed population.
+func (dashboard *Dashboard[T]) Status() DashboardStatus {
+	dashboard.mutex.Lock()
+	status := dashboard.status
+	dashboard.mutex.Unlock()
+
+	if dashboard.Controller != nil {
+		status.Controlled = true
+		status.Paused = dashboard.Controller.Paused()
+	}
+	return status
+}
+
+// History returns the chart data for the most recent generations, in order.
+func (dashboard *Dashboard[T]) History() []DashboardPoint {
+	dashboard.mutex.Lock()
+	defer dashboard.mutex.Unlock()
+
+	history := make([]DashboardPoint, len(dashboard.history))
+	copy(history, dashboard.history)
+	return history
+}
+
+func (dashboard *Dashboard[T]) serveGet(w http.ResponseWriter, r *http.Request, serve func()) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	serve()
+}
+
+func (dashboard *Dashboard[T]) serveControl(w http.ResponseWriter, r *http.Request, control func(Controller)) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	} else if !sameOrigin(r) {
+		http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+		return
+	} else if dashboard.Controller == nil {
+		http.Error(w, "dashboard has no controller", http.StatusNotImplemented)
+		return
+	}
+
+	control(dashboard.Controller)
+	writeJSON(w, dashboard.Status())
+}
+
+// sameOrigin reports whether a request was sent from a page of the same host, or by a client
+// other than a browser, which sends no Origin header.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+const dashboardHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Population dashboard</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+canvas { border: 1px solid #ccc; }
+pre { background: #f4f4f4; padding: 1em; white-space: pre-wrap; word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>Population dashboard</h1>
+<p id="summary">Waiting for the first generation...</p>
+<p id="controls" hidden>
+<button onclick="control('pause')">Pause</button>
+<button onclick="control('resume')">Resume</button>
+<button onclick="control('stop')">Stop</button>
+</p>
+<canvas id="chart" width="800" height="300"></canvas>
+<p>Best fitness in blue, mean fitness in grey.</p>
+<h2>Best genome</h2>
+<pre id="best"></pre>
+<script>
+function control(action) {
+  fetch(action, { method: 'POST' }).then(refresh);
+}
+
+function plot(ctx, history, key, colour, min, max) {
+  ctx.strokeStyle = colour;
+  ctx.beginPath();
+  history.forEach(function (point, i) {
+    var x = history.length > 1 ? i / (history.length - 1) * ctx.canvas.width : 0;
+    var y = ctx.canvas.height - (point[key] - min) / (max - min || 1) * ctx.canvas.height;
+    if (i === 0) ctx.moveTo(x, y); else ctx.lineTo(x, y);
+  });
+  ctx.stroke();
+}
+
+function refresh() {
+  fetch('status').then(function (r) { return r.json(); }).then(function (status) {
+    var summary = 'Generation ' + status.generation + ', size ' + status.size +
+      ', best fitness ' + status.bestFitness + ', evaluations ' + status.evaluations;
+    if (status.diversity !== undefined) summary += ', diversity ' + status.diversity.toFixed(3);
+    if (status.paused) summary += ' (paused)';
+    document.getElementById('summary').textContent = summary;
+    document.getElementById('best').textContent = status.best;
+    document.getElementById('controls').hidden = !status.controlled;
+  });
+  fetch('history').then(function (r) { return r.json(); }).then(function (history) {
+    var ctx = document.getElementById('chart').getContext('2d');
+    ctx.clearRect(0, 0, ctx.canvas.width, ctx.canvas.height);
+    if (history.length === 0) return;
+    var values = history.map(function (p) { return p.bestFitness; }).concat(history.map(function (p) { return p.meanFitness; }));
+    var min = Math.min.apply(null, values), max = Math.max.apply(null, values);
+    plot(ctx, history, 'meanFitness', '#999', min, max);
+    plot(ctx, history, 'bestFitness', '#36c', min, max);
+  });
+}
+
+refresh();
+setInterval(refresh, 1000);
+</script>
+</body>
+</html>
+`
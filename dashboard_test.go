@@ -0,0 +1,133 @@
+package genetic_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+type fakeController struct {
+	paused, stopped bool
+}
+
+func (c *fakeController) Pause()       { c.paused = true }
+func (c *fakeController) Resume()      { c.paused = false }
+func (c *fakeController) Stop()        { c.stopped = true }
+func (c *fakeController) Paused() bool { return c.paused }
+
+func TestDashboard(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	dashboard := genetic.NewDashboard(func(genome []int) string { return fmt.Sprintf("genome %d", genome[0]) })
+	dashboard.Diversity = func(*genetic.Population[[]int]) float64 { return 0.5 }
+	dashboard.HistoryLimit = 3
+	population.Observers = append(population.Observers, dashboard.Observe)
+
+	for i := 0; i < 5; i++ {
+		population.EvolveOnce(1)
+	}
+
+	server := httptest.NewServer(http.StripPrefix("/dashboard", dashboard))
+	defer server.Close()
+
+	get := func(path string, value any) {
+		response, err := server.Client().Get(server.URL + "/dashboard" + path)
+		if err != nil {
+			t.Fatalf("failed to GET %s: %s", path, err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected GET %s to succeed; got %s", path, response.Status)
+		}
+		if err := json.NewDecoder(response.Body).Decode(value); err != nil {
+			t.Fatalf("failed to decode %s: %s", path, err)
+		}
+	}
+
+	var status genetic.DashboardStatus
+	get("/status", &status)
+	if status.Generation != 5 || status.Size != 10 || status.BestFitness != 1 || !strings.HasPrefix(status.Best, "genome ") {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if status.Diversity == nil || *status.Diversity != 0.5 || status.Controlled {
+		t.Errorf("expected status with diversity and no controller; got %+v", status)
+	}
+
+	var history []genetic.DashboardPoint
+	get("/history", &history)
+	if len(history) != 3 || history[0].Generation != 3 || history[2].Generation != 5 {
+		t.Errorf("expected history of the last 3 generations; got %+v", history)
+	}
+
+	response, err := server.Client().Get(server.URL + "/dashboard/")
+	if err != nil {
+		t.Fatalf("failed to GET dashboard page: %s", err)
+	}
+	response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("expected dashboard page to be HTML; got %q", contentType)
+	}
+}
+
+func TestDashboardControls(t *testing.T) {
+	dashboard := genetic.NewDashboard[[]int](nil)
+
+	post := func(path string) int {
+		recorder := httptest.NewRecorder()
+		dashboard.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, nil))
+		return recorder.Code
+	}
+
+	if code := post("/pause"); code != http.StatusNotImplemented {
+		t.Errorf("expected controls to be unavailable without a controller; got %d", code)
+	}
+
+	controller := new(fakeController)
+	dashboard.Controller = controller
+
+	if code := post("/pause"); code != http.StatusOK || !controller.paused {
+		t.Errorf("expected pause to pause the controller; got %d", code)
+	}
+	if !dashboard.Status().Paused {
+		t.Errorf("expected status to report pause")
+	}
+	if code := post("/resume"); code != http.StatusOK || controller.paused {
+		t.Errorf("expected resume to resume the controller; got %d", code)
+	}
+	if code := post("/stop"); code != http.StatusOK || !controller.stopped {
+		t.Errorf("expected stop to stop the controller; got %d", code)
+	}
+
+	recorder := httptest.NewRecorder()
+	dashboard.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stop", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET of a control route to be rejected; got %d", recorder.Code)
+	}
+}
+
+func TestDashboardControlsRejectCrossOrigin(t *testing.T) {
+	dashboard := genetic.NewDashboard[[]int](nil)
+	controller := new(fakeController)
+	dashboard.Controller = controller
+
+	post := func(origin string) int {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/stop", nil)
+		request.Header.Set("Origin", origin)
+		recorder := httptest.NewRecorder()
+		dashboard.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	for _, origin := range []string{"https://example.com", "http://localhost:9090", "null"} {
+		if code := post(origin); code != http.StatusForbidden || controller.stopped {
+			t.Errorf("expected request from origin %q to be rejected; got %d", origin, code)
+		}
+	}
+	if code := post("http://localhost:8080"); code != http.StatusOK || !controller.stopped {
+		t.Errorf("expected same-origin request to stop the controller; got %d", code)
+	}
+}
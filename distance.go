@@ -0,0 +1,85 @@
+package genetic
+
+import (
+	"math"
+)
+
+// HammingDistance is a DistanceFunc for slice genomes, counting the number of loci at which
+// a and b differ. If a and b differ in length, every locus beyond the end of the shorter
+// genome counts as a difference.
+func HammingDistance[E comparable](a, b []E) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	differences := len(b) - len(a)
+	for i := range a {
+		if a[i] != b[i] {
+			differences++
+		}
+	}
+	return float64(differences)
+}
+
+// EuclideanDistance is a DistanceFunc for real-valued genomes of equal length, returning the
+// straight-line distance between a and b.
+func EuclideanDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// KendallTauDistance is a DistanceFunc for permutation genomes, such as orderings of cities in a
+// route, in which a and b are both permutations of the integers 0 to n-1. It returns the number of
+// pairs of elements which appear in a different relative order in a and b, which is the minimum
+// number of swaps of adjacent elements needed to turn a into b. It runs in O(n log n) time.
+func KendallTauDistance(a, b []int) float64 {
+	if len(a) != len(b) {
+		panic("cannot compute Kendall tau distance between permutations of different lengths")
+	}
+
+	// Express b in terms of the positions of its elements in a; the number of
+	// inversions in the resulting sequence is the Kendall tau distance.
+	positions := make([]int, len(a))
+	for i, element := range a {
+		positions[element] = i
+	}
+	sequence := make([]int, len(b))
+	for i, element := range b {
+		sequence[i] = positions[element]
+	}
+
+	return float64(countInversions(sequence, make([]int, len(sequence))))
+}
+
+// countInversions counts the pairs i < j where sequence[i] > sequence[j] by merge sort,
+// sorting sequence in place and using buffer as scratch space.
+func countInversions(sequence, buffer []int) int {
+	if len(sequence) < 2 {
+		return 0
+	}
+
+	mid := len(sequence) / 2
+	inversions := countInversions(sequence[:mid], buffer[:mid]) + countInversions(sequence[mid:], buffer[mid:])
+
+	merged := buffer[:0]
+	i, j := 0, mid
+	for i < mid && j < len(sequence) {
+		if sequence[i] <= sequence[j] {
+			merged = append(merged, sequence[i])
+			i++
+		} else {
+			merged = append(merged, sequence[j])
+			inversions += mid - i
+			j++
+		}
+	}
+	merged = append(merged, sequence[i:mid]...)
+	merged = append(merged, sequence[j:]...)
+	copy(sequence, merged)
+
+	return inversions
+}
@@ -0,0 +1,73 @@
+package genetic_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestHammingDistance(t *testing.T) {
+	fixtures := []struct {
+		a, b     []bool
+		expected float64
+	}{
+		{[]bool{true, false, true}, []bool{true, false, true}, 0},
+		{[]bool{true, false, true}, []bool{false, false, false}, 2},
+		{[]bool{true}, []bool{true, false, false}, 2},
+	}
+
+	for _, fixture := range fixtures {
+		if d := genetic.HammingDistance(fixture.a, fixture.b); d != fixture.expected {
+			t.Errorf("HammingDistance(%v, %v): expected %v; got %v", fixture.a, fixture.b, fixture.expected, d)
+		}
+	}
+}
+
+func TestEuclideanDistance(t *testing.T) {
+	if d := genetic.EuclideanDistance([]float64{1, 2}, []float64{4, 6}); d != 5 {
+		t.Errorf("expected Euclidean distance 5; got %v", d)
+	}
+}
+
+func TestKendallTauDistance(t *testing.T) {
+	fixtures := []struct {
+		a, b     []int
+		expected float64
+	}{
+		{[]int{0, 1, 2, 3}, []int{0, 1, 2, 3}, 0},
+		{[]int{0, 1, 2, 3}, []int{1, 0, 2, 3}, 1},
+		{[]int{0, 1, 2, 3}, []int{3, 2, 1, 0}, 6},
+		{[]int{2, 0, 3, 1}, []int{2, 0, 3, 1}, 0},
+	}
+
+	for _, fixture := range fixtures {
+		if d := genetic.KendallTauDistance(fixture.a, fixture.b); d != fixture.expected {
+			t.Errorf("KendallTauDistance(%v, %v): expected %v; got %v", fixture.a, fixture.b, fixture.expected, d)
+		}
+	}
+
+	// Compare against a naive count of discordant pairs.
+	for trial := 0; trial < 20; trial++ {
+		a, b := rand.Perm(30), rand.Perm(30)
+		positionA, positionB := make([]int, 30), make([]int, 30)
+		for i := range a {
+			positionA[a[i]] = i
+			positionB[b[i]] = i
+		}
+
+		discordant := 0
+		for x := 0; x < 30; x++ {
+			for y := x + 1; y < 30; y++ {
+				if (positionA[x] < positionA[y]) != (positionB[x] < positionB[y]) {
+					discordant++
+				}
+			}
+		}
+
+		if d := genetic.KendallTauDistance(a, b); d != float64(discordant) || math.IsNaN(d) {
+			t.Fatalf("KendallTauDistance(%v, %v): expected %d; got %v", a, b, discordant, d)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package genetic
+
+import (
+	"math"
+)
+
+// DistanceDiversity estimates the diversity of the population as the mean distance between pairs of
+// its genomes, measured by the given distance function. Unlike Diversity, it reflects how different
+// genomes are, rather than only whether they are identical.
+//
+// If samples is positive and fewer than the number of pairs of genomes in the population, the mean
+// is estimated from that many pairs chosen at random, so that the cost of measuring diversity stays
+// constant as the population grows. Otherwise, every pair is compared.
+func (population *Population[T]) DistanceDiversity(distance DistanceFunc[T], samples int) float64 {
+	genomes, _ := population.members()
+	size := len(genomes)
+	pairs := size * (size - 1) / 2
+
+	sum := 0.0
+	if samples <= 0 || samples >= pairs {
+		for i := 0; i < size; i++ {
+			for j := i + 1; j < size; j++ {
+				sum += distance(genomes[i], genomes[j])
+			}
+		}
+		return sum / float64(pairs)
+	}
+
+	for k := 0; k < samples; k++ {
+		pair := randRangeIntsUnique(size, 2)
+		sum += distance(genomes[pair[0]], genomes[pair[1]])
+	}
+	return sum / float64(samples)
+}
+
+// LocusEntropy returns the Shannon entropy, in bits, of the alleles found at each locus across the
+// genomes of a population of slice genomes. Loci at which every genome agrees have zero entropy,
+// revealing which parts of the genome the population has converged upon. If genomes differ in
+// length, each locus is measured over the genomes long enough to have it.
+func LocusEntropy[E comparable](population *Population[[]E]) []float64 {
+	genomes, _ := population.members()
+
+	loci := 0
+	for _, genome := range genomes {
+		if len(genome) > loci {
+			loci = len(genome)
+		}
+	}
+
+	entropies := make([]float64, loci)
+	for locus := range entropies {
+		counts := make(map[E]int)
+		total := 0
+		for _, genome := range genomes {
+			if locus < len(genome) {
+				counts[genome[locus]]++
+				total++
+			}
+		}
+
+		for _, count := range counts {
+			p := float64(count) / float64(total)
+			entropies[locus] -= p * math.Log2(p)
+		}
+	}
+	return entropies
+}
+
+// UniqueGenomes counts the distinct genomes in the population, where genomes are considered
+// identical if the given key function returns equal keys for them, for example a string
+// encoding or hash of the genome. It runs in linear time, unlike Diversity.
+func UniqueGenomes[T any, K comparable](population *Population[T], key func(T) K) int {
+	genomes, _ := population.members()
+
+	seen := make(map[K]struct{}, len(genomes))
+	for _, genome := range genomes {
+		seen[key(genome)] = struct{}{}
+	}
+	return len(seen)
+}
@@ -0,0 +1,47 @@
+package genetic_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestDistanceDiversity(t *testing.T) {
+	population := newTestPopulation(constantFitness, []int{0, 0}, []int{0, 1}, []int{1, 1}, []int{1, 1})
+
+	// Pairwise Hamming distances: 1, 2, 2, 1, 1, 0.
+	if d := population.DistanceDiversity(genetic.HammingDistance[int], 0); d != 7.0/6.0 {
+		t.Errorf("expected exact mean distance 7/6; got %v", d)
+	}
+
+	estimate := population.DistanceDiversity(genetic.HammingDistance[int], 5)
+	if estimate < 0 || estimate > 2 {
+		t.Errorf("expected sampled mean distance between 0 and 2; got %v", estimate)
+	}
+}
+
+func TestLocusEntropy(t *testing.T) {
+	population := newTestPopulation(constantFitness, []int{0, 0, 5}, []int{0, 1, 6}, []int{0, 2, 7}, []int{0, 3})
+	entropies := genetic.LocusEntropy(population)
+
+	expected := []float64{0, 2, math.Log2(3)}
+	if len(entropies) != len(expected) {
+		t.Fatalf("expected %d entropies; got %d", len(expected), len(entropies))
+	}
+	for i := range expected {
+		if math.Abs(entropies[i]-expected[i]) > 1e-9 {
+			t.Errorf("expected entropy %v at locus %d; got %v", expected[i], i, entropies[i])
+		}
+	}
+}
+
+func TestUniqueGenomes(t *testing.T) {
+	population := newTestPopulation(constantFitness, []int{0, 0}, []int{0, 1}, []int{1, 1}, []int{1, 1})
+	key := func(genome []int) string { return fmt.Sprint(genome) }
+
+	if unique := genetic.UniqueGenomes(population, key); unique != 3 {
+		t.Errorf("expected 3 unique genomes; got %d", unique)
+	}
+}
@@ -1,12 +1,29 @@
 package genetic
 
+import (
+	"math"
+)
+
+// UnknownFitness is the placeholder value of fitnesses which have not yet been computed.
+// It is distinct from any fitness a genome might reasonably have, including zero.
+const UnknownFitness = math.MinInt
+
+// unknownFitnesses returns a slice of n fitnesses, all of which are UnknownFitness.
+func unknownFitnesses(n int) []int {
+	fitnesses := make([]int, n)
+	for i := range fitnesses {
+		fitnesses[i] = UnknownFitness
+	}
+	return fitnesses
+}
+
 // StaticFitnessFunc is a utility which maps a static non-competitive fitness function,
 // whose output is not dependent on other competing genomes, into a FitnessFunc[T].
 // Use this if your genomes' fitnesses are measured independently of the wider population.
 func StaticFitnessFunc[T any](fitness func(T) int) FitnessFunc[T] {
-	return func(genomes []T, fitnesses []int) {
+	return func(genomes []T, fitnesses []int, evaluated []bool) {
 		for i, genome := range genomes {
-			if fitnesses[i] == 0 {
+			if !evaluated[i] {
 				// Only calculate fitness for genomes whose fitnesses are unknown.
 				fitnesses[i] = fitness(genome)
 			}
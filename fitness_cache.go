@@ -0,0 +1,117 @@
+package genetic
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// FitnessCache memoizes a static fitness function, so that the fitness of genetically identical
+// genomes is only computed once. Genomes are identified by a comparable key computed from each
+// genome, such as a hash or a string encoding of its DNA. The cache holds a bounded number of
+// fitnesses, evicting the least recently used when full.
+//
+// A FitnessCache is safe for concurrent use.
+type FitnessCache[T any, K comparable] struct {
+	capacity int
+	key      func(T) K
+	fitness  func(T) int
+
+	mutex   sync.Mutex
+	entries map[K]*list.Element
+	recency *list.List
+	hits    int
+	misses  int
+}
+
+type fitnessCacheEntry[K comparable] struct {
+	key     K
+	fitness int
+}
+
+// NewFitnessCache creates a FitnessCache which holds up to capacity fitnesses. The key function
+// must return equal keys only for genomes which have equal fitness.
+func NewFitnessCache[T any, K comparable](capacity int, key func(T) K, fitness func(T) int) *FitnessCache[T, K] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("invalid fitness cache capacity: %d", capacity))
+	} else if key == nil {
+		panic("expected to receive key function")
+	} else if fitness == nil {
+		panic("expected to receive fitness function")
+	}
+
+	return &FitnessCache[T, K]{
+		capacity: capacity,
+		key:      key,
+		fitness:  fitness,
+		entries:  make(map[K]*list.Element),
+		recency:  list.New(),
+	}
+}
+
+// Fitness returns the fitness of the given genome, computing it only if the fitness of a genome
+// with the same key is not already cached.
+func (cache *FitnessCache[T, K]) Fitness(genome T) int {
+	key := cache.key(genome)
+
+	cache.mutex.Lock()
+	if element, ok := cache.entries[key]; ok {
+		cache.hits++
+		cache.recency.MoveToFront(element)
+		fitness := element.Value.(*fitnessCacheEntry[K]).fitness
+		cache.mutex.Unlock()
+		return fitness
+	}
+	cache.misses++
+	cache.mutex.Unlock()
+
+	// The fitness is computed without holding the lock, so that slow fitness
+	// functions do not block concurrent lookups of other genomes.
+	fitness := cache.fitness(genome)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		// Another goroutine computed the same fitness concurrently.
+		cache.recency.MoveToFront(element)
+		return fitness
+	}
+
+	cache.entries[key] = cache.recency.PushFront(&fitnessCacheEntry[K]{key: key, fitness: fitness})
+	if cache.recency.Len() > cache.capacity {
+		oldest := cache.recency.Back()
+		cache.recency.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*fitnessCacheEntry[K]).key)
+	}
+
+	return fitness
+}
+
+// FitnessFunc returns a FitnessFunc[T] which computes the fitness of each genome which has not
+// yet been evaluated through the cache. Like StaticFitnessFunc, it is suitable only for fitnesses
+// which do not depend on the wider population.
+func (cache *FitnessCache[T, K]) FitnessFunc() FitnessFunc[T] {
+	return StaticFitnessFunc(cache.Fitness)
+}
+
+// Hits returns the number of fitness lookups which were answered from the cache.
+func (cache *FitnessCache[T, K]) Hits() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.hits
+}
+
+// Misses returns the number of fitness lookups which required the fitness to be computed.
+func (cache *FitnessCache[T, K]) Misses() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.misses
+}
+
+// Len returns the number of fitnesses currently cached.
+func (cache *FitnessCache[T, K]) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.recency.Len()
+}
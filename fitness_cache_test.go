@@ -0,0 +1,59 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestFitnessCache(t *testing.T) {
+	computed := 0
+	cache := genetic.NewFitnessCache(2, func(genome []int) int { return genome[0] }, func(genome []int) int {
+		computed++
+		return genome[0] * 10
+	})
+
+	lookups := []int{1, 2, 1, 3, 2, 1}
+	expectedComputed := []int{1, 2, 2, 3, 4, 5}
+	for i, x := range lookups {
+		if fitness := cache.Fitness([]int{x}); fitness != x*10 {
+			t.Fatalf("expected fitness %d; got %d", x*10, fitness)
+		}
+		if computed != expectedComputed[i] {
+			t.Fatalf("after lookup %d: expected %d computations; got %d", i, expectedComputed[i], computed)
+		}
+	}
+
+	if cache.Hits() != 1 || cache.Misses() != 5 {
+		t.Errorf("unexpected hit/miss counts: %d hits, %d misses", cache.Hits(), cache.Misses())
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected cache to be bounded to 2 entries; got %d", cache.Len())
+	}
+}
+
+func TestFitnessCacheFitnessFunc(t *testing.T) {
+	computed := 0
+	cache := genetic.NewFitnessCache(10, func(genome []int) int { return genome[0] }, func(genome []int) int {
+		computed++
+		return 0
+	})
+	fitness := cache.FitnessFunc()
+
+	genomes := [][]int{{1}, {2}, {1}}
+	fitnesses := []int{genetic.UnknownFitness, 0, genetic.UnknownFitness}
+	fitness(genomes, fitnesses, []bool{false, true, false})
+
+	// The known zero fitness must not be recomputed, and the duplicate genome must hit the cache.
+	if computed != 1 {
+		t.Errorf("expected fitness to be computed once; got %d", computed)
+	}
+	if cache.Hits() != 1 {
+		t.Errorf("expected duplicate genome to hit cache; got %d hits", cache.Hits())
+	}
+	for i, f := range fitnesses {
+		if f != 0 {
+			t.Errorf("expected fitness %d to be 0; got %d", i, f)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestStaticFitnessFunc(t *testing.T) {
+	computed := 0
+	fitness := genetic.StaticFitnessFunc(func(genome int) int {
+		computed++
+		return genome * 2
+	})
+
+	genomes := []int{1, 2, 3}
+	fitnesses := []int{0, genetic.UnknownFitness, genetic.UnknownFitness}
+	fitness(genomes, fitnesses, []bool{true, false, false})
+
+	if computed != 2 {
+		t.Errorf("expected only unevaluated fitnesses to be computed; computed %d", computed)
+	}
+	if fitnesses[0] != 0 || fitnesses[1] != 4 || fitnesses[2] != 6 {
+		t.Errorf("unexpected fitnesses: %v", fitnesses)
+	}
+}
+
+func TestPopulationEvaluatedMask(t *testing.T) {
+	elitism := 3
+	checkedMask := false
+
+	population := newTestPopulation(
+		func(genomes [][]int, fitnesses []int, evaluated []bool) {
+			for i := range genomes {
+				if evaluated[i] {
+					// Every genome has a genuine fitness of zero, which must still be cached.
+					if fitnesses[i] != 0 {
+						t.Errorf("expected cached fitness of 0; got %d", fitnesses[i])
+					}
+					if i >= elitism {
+						t.Errorf("expected only elites to be marked as evaluated; got index %d", i)
+					}
+					checkedMask = true
+				} else {
+					if fitnesses[i] != genetic.UnknownFitness {
+						t.Errorf("expected unevaluated fitness to be UnknownFitness; got %d", fitnesses[i])
+					}
+					fitnesses[i] = 0
+				}
+			}
+		},
+		countingGenomes(10)...,
+	)
+
+	population.EvolveOnce(elitism)
+	if !checkedMask {
+		t.Errorf("expected elite fitnesses to be marked as evaluated")
+	}
+}
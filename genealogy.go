@@ -0,0 +1,167 @@
+package genetic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GenealogyNode records the birth of a single individual in a Genealogy.
+type GenealogyNode struct {
+	ID              uint64   `json:"id"`
+	BirthGeneration int      `json:"birthGeneration"`
+	Fitness         int      `json:"fitness"`
+	Parents         []uint64 `json:"parents,omitempty"`
+	Operators       []string `json:"operators,omitempty"`
+}
+
+// Genealogy is a parent-child graph of every individual born into a Population, including
+// those which never survived selection. Assign a Genealogy to Population.Genealogy to have it
+// recorded as the population evolves. Since every individual is recorded, a Genealogy grows
+// without bound, by one node per fitness evaluation.
+//
+// A Genealogy is safe for concurrent use, so it may be exported while evolution continues.
+type Genealogy struct {
+	mutex sync.Mutex
+	nodes map[uint64]*GenealogyNode
+}
+
+// NewGenealogy creates an empty Genealogy.
+func NewGenealogy() *Genealogy {
+	return &Genealogy{nodes: make(map[uint64]*GenealogyNode)}
+}
+
+// record adds the given individuals to the genealogy, if they have not been recorded already.
+func (genealogy *Genealogy) record(individuals []Individual, fitnesses []int) {
+	genealogy.mutex.Lock()
+	defer genealogy.mutex.Unlock()
+
+	for i, individual := range individuals {
+		if _, ok := genealogy.nodes[individual.ID]; ok {
+			continue
+		}
+		genealogy.nodes[individual.ID] = &GenealogyNode{
+			ID:              individual.ID,
+			BirthGeneration: individual.BirthGeneration,
+			Fitness:         fitnesses[i],
+			Parents:         individual.Parents,
+			Operators:       individual.Operators,
+		}
+	}
+}
+
+// Len returns the number of individuals recorded in the genealogy.
+func (genealogy *Genealogy) Len() int {
+	genealogy.mutex.Lock()
+	defer genealogy.mutex.Unlock()
+	return len(genealogy.nodes)
+}
+
+// Node returns the node recording the individual with the given ID, if any.
+func (genealogy *Genealogy) Node(id uint64) (GenealogyNode, bool) {
+	genealogy.mutex.Lock()
+	defer genealogy.mutex.Unlock()
+
+	node, ok := genealogy.nodes[id]
+	if !ok {
+		return GenealogyNode{}, false
+	}
+	return *node, true
+}
+
+// Nodes returns every node in the genealogy, in ascending order of ID.
+func (genealogy *Genealogy) Nodes() []GenealogyNode {
+	genealogy.mutex.Lock()
+	defer genealogy.mutex.Unlock()
+	return genealogy.sortedNodes()
+}
+
+func (genealogy *Genealogy) sortedNodes() []GenealogyNode {
+	nodes := make([]GenealogyNode, 0, len(genealogy.nodes))
+	for _, node := range genealogy.nodes {
+		nodes = append(nodes, *node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// Lineage returns a new Genealogy containing only the individual with the given ID and
+// all of its recorded ancestors. Use it to trace how a particular genome, such as the
+// best genome of a population, was assembled.
+func (genealogy *Genealogy) Lineage(id uint64) *Genealogy {
+	genealogy.mutex.Lock()
+	defer genealogy.mutex.Unlock()
+
+	lineage := NewGenealogy()
+	pending := []uint64{id}
+	for len(pending) > 0 {
+		id, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		if _, ok := lineage.nodes[id]; ok {
+			continue
+		}
+
+		node, ok := genealogy.nodes[id]
+		if !ok {
+			continue
+		}
+		copied := *node
+		lineage.nodes[id] = &copied
+		pending = append(pending, node.Parents...)
+	}
+
+	return lineage
+}
+
+// OperatorCounts returns the number of times each operator was applied to produce the
+// individuals in the genealogy. On a Lineage, this shows which operators contributed
+// most to a particular genome.
+func (genealogy *Genealogy) OperatorCounts() map[string]int {
+	genealogy.mutex.Lock()
+	defer genealogy.mutex.Unlock()
+
+	counts := make(map[string]int)
+	for _, node := range genealogy.nodes {
+		for _, operator := range node.Operators {
+			counts[operator]++
+		}
+	}
+	return counts
+}
+
+// dotLabelEscaper escapes text for use in a quoted DOT label.
+var dotLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// WriteDOT writes the genealogy to w as a Graphviz DOT digraph, with an edge
+// from each parent to each of its children.
+func (genealogy *Genealogy) WriteDOT(w io.Writer) error {
+	genealogy.mutex.Lock()
+	nodes := genealogy.sortedNodes()
+	genealogy.mutex.Unlock()
+
+	var b strings.Builder
+	b.WriteString("digraph genealogy {\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&b, "\t%d [label=\"#%d\\ngeneration %d\\nfitness %d\\n%s\"];\n",
+			node.ID, node.ID, node.BirthGeneration, node.Fitness, dotLabelEscaper.Replace(strings.Join(node.Operators, "+")))
+	}
+	for _, node := range nodes {
+		for _, parent := range node.Parents {
+			fmt.Fprintf(&b, "\t%d -> %d;\n", parent, node.ID)
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the genealogy to w as a JSON array of nodes, in ascending order of ID.
+func (genealogy *Genealogy) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(genealogy.Nodes())
+}
@@ -0,0 +1,99 @@
+package genetic_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestGenealogy(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Genealogy = genetic.NewGenealogy()
+
+	size := len(population.Individuals())
+	generations := 5
+	for i := 0; i < generations; i++ {
+		population.EvolveOnce(2)
+	}
+
+	// Every initial member and every child bred must be recorded.
+	if n := population.Genealogy.Len(); n < size*(generations+1) {
+		t.Errorf("expected at least %d recorded individuals; got %d", size*(generations+1), n)
+	}
+
+	best := population.Individuals()[0]
+	lineage := population.Genealogy.Lineage(best.ID)
+	node, ok := lineage.Node(best.ID)
+	if !ok {
+		t.Fatalf("expected lineage to contain best individual %d", best.ID)
+	}
+	_, bestFitness := population.Best()
+	if node.Fitness != bestFitness {
+		t.Errorf("expected recorded fitness %d; got %d", bestFitness, node.Fitness)
+	}
+	for _, parent := range node.Parents {
+		if _, ok := lineage.Node(parent); !ok {
+			t.Errorf("expected lineage to contain parent %d", parent)
+		}
+	}
+
+	counts := lineage.OperatorCounts()
+	if best.BirthGeneration > 0 && (counts[genetic.OperatorGenesis] == 0 || counts[genetic.OperatorCrossover] == 0) {
+		t.Errorf("expected lineage of bred individual to include genesis and crossover; got %v", counts)
+	}
+
+	var dot bytes.Buffer
+	if err := lineage.WriteDOT(&dot); err != nil {
+		t.Fatalf("failed to write DOT: %s", err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph genealogy {") {
+		t.Errorf("unexpected DOT output:\n%s", dot.String())
+	}
+	if len(node.Parents) > 0 && !strings.Contains(dot.String(), fmt.Sprintf("%d -> %d;", node.Parents[0], best.ID)) {
+		t.Errorf("expected DOT output to contain edge from parent to best individual:\n%s", dot.String())
+	}
+
+	var encoded bytes.Buffer
+	if err := population.Genealogy.WriteJSON(&encoded); err != nil {
+		t.Fatalf("failed to write JSON: %s", err)
+	}
+	var nodes []genetic.GenealogyNode
+	if err := json.Unmarshal(encoded.Bytes(), &nodes); err != nil {
+		t.Fatalf("failed to decode JSON genealogy: %s", err)
+	}
+	if len(nodes) != population.Genealogy.Len() {
+		t.Errorf("expected %d JSON nodes; got %d", population.Genealogy.Len(), len(nodes))
+	}
+}
+
+func TestGenealogyDOTEscapesOperators(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Genealogy = genetic.NewGenealogy()
+	population.Operators = genetic.NewProbabilityMatching(0.1, 0.3,
+		genetic.Operator[[]int]{Name: `swap "fast"`},
+		genetic.Operator[[]int]{Name: `back\slash`},
+	)
+	population.EvolveOnce(0)
+
+	var dot bytes.Buffer
+	if err := population.Genealogy.WriteDOT(&dot); err != nil {
+		t.Fatalf("failed to write DOT: %s", err)
+	}
+
+	output := dot.String()
+	if !strings.Contains(output, `swap \"fast\"`) || !strings.Contains(output, `back\\slash`) {
+		t.Errorf("expected operator names to be escaped in DOT labels:\n%s", output)
+	}
+
+	// Once escape sequences are removed, each label must be a single quoted string.
+	unescaper := strings.NewReplacer(`\\`, "", `\"`, "")
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "label=") && strings.Count(unescaper.Replace(line), `"`) != 2 {
+			t.Errorf("malformed DOT label: %s", line)
+		}
+	}
+}
@@ -4,7 +4,11 @@ package genetic
 
 import (
 	"fmt"
+	"log/slog"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // PopulationSizeMinimum is the minimum size of a Population.
@@ -20,10 +24,11 @@ type CrossoverFunc[T any] func(male, female T) (T, T)
 // FitnessFunc calculates the fitnesses of all genomes in a population,
 // storing the results in the given fitnesses slice.
 //
-// Some entries in fitnesses may be prepopulated - these are cached fitnesses for elite
-// genomes surviving from the previous generation. A FitnessFunc may recalculate or
-// skip them as needed.
-type FitnessFunc[T any] func(allGenomes []T, fitnesses []int)
+// The evaluated slice marks which entries in fitnesses are already known. Where evaluated[i]
+// is true, fitnesses[i] is prepopulated with the cached fitness of an elite genome surviving
+// from the previous generation, which a FitnessFunc may recalculate or skip as needed. Where
+// evaluated[i] is false, fitnesses[i] is UnknownFitness, and the FitnessFunc must compute it.
+type FitnessFunc[T any] func(allGenomes []T, fitnesses []int, evaluated []bool)
 
 // MutationFunc randomly alters the DNA of the given genome, in the hopes that
 // some mutatations will result in fitter genomes.
@@ -31,17 +36,66 @@ type MutationFunc[T any] func(T)
 
 // SelectionFunc selects pairs of mates from a given population of genomes.
 // A SelectionFunc is passed a slice of genomes and a corresponding slice of their
-// respective pre-computed fitnesses. It should return a slice of mating pairs
-// whose length is such that len(matingPairs)*2 >= len(genomes).
+// respective pre-computed fitnesses. It should return a slice of mating pairs, each
+// given as a pair of indexes into genomes, whose length is such that
+// len(matingPairs)*2 >= len(genomes).
 //
 // A SelectionFunc should NOT mutate the values passed to it.
-type SelectionFunc[T any] func(genomes []T, fitnesses []int) (matingPairs [][2]T)
+type SelectionFunc[T any] func(genomes []T, fitnesses []int) (matingPairs [][2]int)
+
+// PairSelectionFunc selects a single pair of mates from a given population of genomes, for
+// use in steady-state evolution, where only one pair of mates is needed at a time. It is
+// passed the same values as a SelectionFunc, and should return a pair of indexes into genomes.
+//
+// A PairSelectionFunc should NOT mutate the values passed to it.
+type PairSelectionFunc[T any] func(genomes []T, fitnesses []int) (matingPair [2]int)
 
 // Population is a struct representing a population of individuals (genomes of
 // type T) which can be evolved using genetic algorithms.
+//
+// A Population may only be evolved by one goroutine at a time, and its exported fields must not
+// be changed while it is evolving. Its read-only methods, such as Best, Snapshot, Individuals and
+// Generation, are safe to call from other goroutines at any time, including during evolution,
+// and see the population as it was at the end of a generation. Genomes are shared with the
+// population rather than copied, so they must not be modified by readers. Likewise, genomes are
+// never modified by the population once they join it, unless its CrossoverFunc returns its
+// arguments rather than new genomes, as AsexualCrossover does.
 type Population[T any] struct {
-	genomes   []T
-	fitnesses []int
+	// Evaluation counters are updated atomically, as fitness may be evaluated
+	// concurrently during asynchronous evolution.
+	evaluations           int64
+	pendingEvaluations    int64
+	pendingEvaluationTime int64
+	lastObserved          time.Time
+
+	// timesMutex guards pendingEvaluationTimes, which is appended to by every call to evaluate.
+	timesMutex             sync.Mutex
+	pendingEvaluationTimes []time.Duration
+
+	genomes     []T
+	fitnesses   []int
+	individuals []Individual
+	generation  int
+	lastID      uint64
+	genesis     GenesisFunc[T]
+
+	// publishedGeneration is the generation of the members, as seen by readers. The
+	// generation counter is advanced before breeding, and published by adopt along
+	// with the members bred in that generation.
+	publishedGeneration int
+
+	bestFitness         int
+	stagnantFitness     int
+	stagnantGenerations int
+	restarts            []RestartEvent
+
+	// mutex serializes the workers of asynchronous evolution.
+	mutex sync.Mutex
+
+	// state guards the fields read by the population's read-only methods. Since the
+	// member slices are replaced rather than modified, only the evolving goroutine's
+	// writes and other goroutines' reads need to hold it.
+	state sync.RWMutex
 
 	// Crossover is used to recombine two genomes of type T.
 	Crossover CrossoverFunc[T]
@@ -52,8 +106,44 @@ type Population[T any] struct {
 	// Selection selects which genomes will reproduce, and which genomes they will mate with.
 	Selection SelectionFunc[T]
 
+	// PairSelection optionally selects the single pair of mates used by each step of
+	// steady-state and asynchronous evolution. If nil, the first pair returned by Selection
+	// is used, at the cost of selecting a whole generation's worth of mating pairs.
+	PairSelection PairSelectionFunc[T]
+
 	// Mutation randomly mutates a genome.
 	Mutation MutationFunc[T]
+
+	// Replacement optionally decides which parents and children survive into the next
+	// generation. If nil, the elite parents and all children survive.
+	Replacement ReplacementFunc[T]
+
+	// Operators optionally chooses between several crossover and mutation operators
+	// when breeding, adapting to favour those which produce fitter children. If nil,
+	// every child is bred using Crossover and Mutation.
+	Operators *OperatorSelector[T]
+
+	// Sizing optionally resizes the population after each generation. If nil, the population
+	// keeps its size, unless changed by its ReplacementFunc.
+	Sizing SizingFunc[T]
+
+	// RestartPolicy optionally restarts the population when its evolution stagnates. It is
+	// checked after every generation or steady-state step, including those of EvolveAsync.
+	RestartPolicy *RestartPolicy
+
+	// Observers are called after every generation, or steady-state step, with statistics
+	// about it. See ObserverFunc.
+	Observers []ObserverFunc[T]
+
+	// Logger optionally receives structured log events about the population's evolution.
+	// Each generation is logged at debug level; new best fitnesses, restarts, resizes and
+	// injected genomes at info level; and panics raised by the population's operators at
+	// error level. If nil, nothing is logged.
+	Logger *slog.Logger
+
+	// Genealogy optionally records the parent-child graph of every individual born
+	// into the population.
+	Genealogy *Genealogy
 }
 
 // NewPopulation initializes a Population of genomes of the given size.
@@ -66,6 +156,21 @@ func NewPopulation[T any](
 	selection SelectionFunc[T],
 	mutation MutationFunc[T],
 ) *Population[T] {
+	return NewSeededPopulation(size, nil, generate, crossover, fitness, selection, mutation)
+}
+
+// NewSeededPopulation initializes a Population of genomes of the given size, which includes
+// the given seed genomes, such as solutions found by a heuristic or by a previous run. The
+// generate function is used to create random genomes to fill the rest of the population.
+func NewSeededPopulation[T any](
+	size int,
+	seeds []T,
+	generate GenesisFunc[T],
+	crossover CrossoverFunc[T],
+	fitness FitnessFunc[T],
+	selection SelectionFunc[T],
+	mutation MutationFunc[T],
+) *Population[T] {
 
 	if size < PopulationSizeMinimum {
 		panic(fmt.Sprintf("Population size minimum is %d; got %d", PopulationSizeMinimum, size))
@@ -77,24 +182,37 @@ func NewPopulation[T any](
 		panic("expected to receive FitnessFunc")
 	} else if selection == nil {
 		panic("expected to receive SelectionFunc")
+	} else if len(seeds) > size {
+		panic(fmt.Sprintf("cannot seed population of size %d with %d genomes", size, len(seeds)))
 	}
 
 	population := &Population[T]{
-		genomes:   make([]T, size),
-		fitnesses: make([]int, size),
-		Crossover: crossover,
-		Fitness:   fitness,
-		Selection: selection,
-		Mutation:  mutation,
+		genomes:     make([]T, size),
+		fitnesses:   unknownFitnesses(size),
+		individuals: make([]Individual, size),
+		genesis:     generate,
+		Crossover:   crossover,
+		Fitness:     fitness,
+		Selection:   selection,
+		Mutation:    mutation,
 	}
 
-	for i := 0; i < size; i++ {
+	for i, seed := range seeds {
+		population.genomes[i] = seed
+		population.individuals[i] = population.newIndividual(nil, OperatorSeed)
+	}
+
+	for i := len(seeds); i < size; i++ {
 		genome := generate()
 		population.genomes[i] = genome
+		population.individuals[i] = population.newIndividual(nil, OperatorGenesis)
 	}
 
-	fitness(population.genomes, population.fitnesses)
-	sortWithValues(sortDescending, population.genomes, population.fitnesses)
+	population.evaluate(population.genomes, population.fitnesses, 0)
+	population.adopt(population.genomes, population.fitnesses, population.individuals, size)
+	population.stagnantFitness = population.fitnesses[0]
+	population.bestFitness = population.fitnesses[0]
+	population.resetObservation()
 
 	return population
 }
@@ -102,37 +220,118 @@ func NewPopulation[T any](
 // EvolveOnce evolves the population by one generation, replacing the current population
 // with their children. It calls the population's selection function once, its fitness
 // function once, and crossover once for every mating pair needed to repopulate.
+//
+// The given number of elite genomes are carried over into the next generation unchanged.
+// If the population has a ReplacementFunc, elitism is ignored, and the ReplacementFunc
+// instead decides which parents and children survive.
+//
+// If the population has a SizingFunc, the population is then resized as it decides.
+// If the population has a RestartPolicy whose trigger has been reached by the end of the
+// generation, the population is restarted.
 func (population *Population[T]) EvolveOnce(elitism int) {
+	defer population.logPanic()
+
 	elitism = max(elitism, 0)
+	if population.Replacement != nil {
+		elitism = 0
+	}
 	matingPairs := population.Selection(population.genomes, population.fitnesses)
 
-	childGenomes := make([]T, 0, len(matingPairs)*2+elitism)
-	if cap(childGenomes) < len(population.genomes) {
+	if len(matingPairs)*2+elitism < len(population.genomes) {
 		panic("too few mating pairs returned by population's SelectionFunc")
 	}
 
-	for _, matingPair := range matingPairs {
-		offspring1, offspring2 := population.Crossover(matingPair[0], matingPair[1])
-		if population.Mutation != nil {
-			population.Mutation(offspring1)
-			population.Mutation(offspring2)
-		}
-		childGenomes = append(childGenomes, offspring1, offspring2)
+	population.nextGeneration()
+	childGenomes, childIndividuals := population.breed(matingPairs)
+
+	if population.Replacement != nil {
+		population.evolveWithReplacement(childGenomes, childIndividuals, matingPairs)
+	} else {
+		population.evolveWithElitism(childGenomes, childIndividuals, elitism)
 	}
 
+	population.applySizing()
+	population.checkRestart()
+	population.notifyObservers()
+}
+
+// evolveWithReplacement evaluates the children, and then chooses survivors from the
+// current generation and the children using the population's ReplacementFunc.
+func (population *Population[T]) evolveWithReplacement(childGenomes []T, childIndividuals []Individual, matingPairs [][2]int) {
+	poolGenomes := append(append(make([]T, 0, len(population.genomes)+len(childGenomes)), population.genomes...), childGenomes...)
+	poolFitnesses := unknownFitnesses(len(poolGenomes))
+	copy(poolFitnesses, population.fitnesses)
+	poolIndividuals := append(append(make([]Individual, 0, len(poolGenomes)), population.individuals...), childIndividuals...)
+
+	population.evaluate(poolGenomes, poolFitnesses, len(population.genomes))
+	population.creditOperators(childIndividuals, poolFitnesses[len(population.genomes):])
+	population.replace(poolGenomes, poolFitnesses, poolIndividuals, matingPairs, population.Replacement)
+}
+
+// evolveWithElitism evaluates the children, and then replaces the current generation with
+// the elite genomes and the fittest children.
+func (population *Population[T]) evolveWithElitism(childGenomes []T, childIndividuals []Individual, elitism int) {
 	nextGenomes := make([]T, len(childGenomes)+elitism)
 	copy(nextGenomes, population.genomes[:elitism])
 	copy(nextGenomes[elitism:], childGenomes)
 
-	nextFitnesses := make([]int, len(childGenomes)+elitism)
+	nextFitnesses := unknownFitnesses(len(childGenomes) + elitism)
 	copy(nextFitnesses, population.fitnesses[:elitism])
 
-	population.Fitness(nextGenomes, nextFitnesses)
+	nextIndividuals := make([]Individual, len(childGenomes)+elitism)
+	copy(nextIndividuals, aged(population.individuals[:elitism]))
+	copy(nextIndividuals[elitism:], childIndividuals)
+
+	population.evaluate(nextGenomes, nextFitnesses, elitism)
+	population.creditOperators(childIndividuals, nextFitnesses[elitism:])
+
+	population.adopt(nextGenomes, nextFitnesses, nextIndividuals, len(population.genomes))
+}
+
+// evaluate calls the population's fitness function on the given genomes, of which
+// only the first known fitnesses have already been computed.
+func (population *Population[T]) evaluate(genomes []T, fitnesses []int, known int) {
+	evaluated := make([]bool, len(genomes))
+	for i := 0; i < known; i++ {
+		evaluated[i] = true
+	}
+
+	start := time.Now()
+	population.Fitness(genomes, fitnesses, evaluated)
+	elapsed := time.Since(start)
+
+	count := int64(len(genomes) - known)
+	atomic.AddInt64(&population.evaluations, count)
+	atomic.AddInt64(&population.pendingEvaluations, count)
+	atomic.AddInt64(&population.pendingEvaluationTime, int64(elapsed))
+
+	if count > 0 {
+		population.timesMutex.Lock()
+		population.pendingEvaluationTimes = append(population.pendingEvaluationTimes, elapsed)
+		population.timesMutex.Unlock()
+	}
+}
+
+// adopt replaces the members of the population with the fittest size genomes among the
+// given genomes, which are stored in descending order of fitness.
+func (population *Population[T]) adopt(genomes []T, fitnesses []int, individuals []Individual, size int) {
+	if population.Genealogy != nil {
+		// The current members are recorded too, in case the genealogy was
+		// assigned after they were born.
+		population.Genealogy.record(population.individuals, population.fitnesses)
+		population.Genealogy.record(individuals, fitnesses)
+	}
+
+	order := sortedIndexes(sortDescending, fitnesses)[:size]
+	genomes, fitnesses, individuals = permute(genomes, order), permute(fitnesses, order), permute(individuals, order)
 
-	sortWithValues(sortDescending, nextGenomes, nextFitnesses)
+	population.state.Lock()
+	defer population.state.Unlock()
 
-	population.genomes = nextGenomes[:len(population.genomes)]
-	population.fitnesses = nextFitnesses[:len(population.fitnesses)]
+	population.genomes = genomes
+	population.fitnesses = fitnesses
+	population.individuals = individuals
+	population.publishedGeneration = population.generation
 }
 
 // Evolve evolves the population until either a genome is produced which meets the
@@ -151,7 +350,8 @@ func (population *Population[T]) Evolve(fitnessThreshold, maxGenerations, elitis
 
 // Best returns the current population's fittest genome and fitness.
 func (population *Population[T]) Best() (T, int) {
-	return population.genomes[0], population.fitnesses[0]
+	genomes, fitnesses := population.members()
+	return genomes[0], fitnesses[0]
 }
 
 // Diversity compares every genome in the population with one another using reflect.DeepEqual to determine
@@ -159,13 +359,15 @@ func (population *Population[T]) Best() (T, int) {
 // which were NOT identical.
 //
 // The total number of DeepEqual comparison calls made will be ((s-1)^2 + (s-1)) / 2, where s is the population size.
+// For large populations, DistanceDiversity and UniqueGenomes are faster and more informative.
 func (population *Population[T]) Diversity() float64 {
 	sames := float64(0)
 	opportunities := float64(0)
+	genomes, _ := population.members()
 
-	for i, g1 := range population.genomes {
-		for j := i + 1; j < len(population.genomes); j++ {
-			g2 := population.genomes[j]
+	for i, g1 := range genomes {
+		for j := i + 1; j < len(genomes); j++ {
+			g2 := genomes[j]
 			opportunities += 1.0
 			if reflect.DeepEqual(g1, g2) {
 				sames += 1.0
@@ -1,5 +1,5 @@
 module github.com/kklash/genetic
 
-go 1.18
+go 1.21
 
 require github.com/kklash/bits v1.1.0
@@ -0,0 +1,86 @@
+package genetic
+
+import (
+	"sort"
+)
+
+// Hypervolume calculates the volume of objective space which is dominated by the given set of
+// objective vectors, and which dominates the given reference point. Objectives are maximized, so
+// the reference point should be worse than every point of interest on every objective; points
+// which do not strictly exceed the reference point on every objective contribute nothing.
+//
+// Hypervolume is a standard indicator to compare the quality of Pareto fronts produced by
+// different multi-objective runs: a larger hypervolume is better. Only 2 and 3 objectives
+// are supported.
+func Hypervolume(points [][]float64, reference []float64) float64 {
+	if len(reference) != 2 && len(reference) != 3 {
+		panic("hypervolume can only be calculated for 2 or 3 objectives")
+	}
+
+	var relevant [][]float64
+	for _, point := range points {
+		if len(point) != len(reference) {
+			panic("cannot compute hypervolume of points with mismatching dimensions")
+		}
+		if dominatesStrictly(point, reference) {
+			relevant = append(relevant, point)
+		}
+	}
+
+	if len(reference) == 2 {
+		return hypervolume2D(relevant, reference)
+	}
+	return hypervolume3D(relevant, reference)
+}
+
+func dominatesStrictly(a, b []float64) bool {
+	for i := range a {
+		if a[i] <= b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hypervolume2D sweeps across the points in order of decreasing first objective, adding the
+// rectangle each point contributes above the highest second objective seen so far.
+func hypervolume2D(points [][]float64, reference []float64) float64 {
+	sorted := make([][]float64, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i][0] > sorted[j][0]
+	})
+
+	volume := 0.0
+	highestY := reference[1]
+	for _, point := range sorted {
+		if point[1] > highestY {
+			volume += (point[0] - reference[0]) * (point[1] - highestY)
+			highestY = point[1]
+		}
+	}
+	return volume
+}
+
+// hypervolume3D slices the dominated space along the third objective. Each slab between two
+// consecutive third-objective values is the 2D hypervolume of every point above it, multiplied
+// by the slab's depth.
+func hypervolume3D(points [][]float64, reference []float64) float64 {
+	sorted := make([][]float64, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i][2] > sorted[j][2]
+	})
+
+	volume := 0.0
+	for i, point := range sorted {
+		nextZ := reference[2]
+		if i+1 < len(sorted) {
+			nextZ = sorted[i+1][2]
+		}
+		if depth := point[2] - nextZ; depth > 0 {
+			volume += hypervolume2D(sorted[:i+1], reference[:2]) * depth
+		}
+	}
+	return volume
+}
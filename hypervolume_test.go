@@ -0,0 +1,57 @@
+package genetic_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestHypervolume(t *testing.T) {
+	type Fixture struct {
+		points    [][]float64
+		reference []float64
+		volume    float64
+	}
+
+	fixtures := []*Fixture{
+		{
+			points:    [][]float64{{1, 1}},
+			reference: []float64{0, 0},
+			volume:    1,
+		},
+		{
+			points:    [][]float64{{1, 3}, {2, 2}, {3, 1}},
+			reference: []float64{0, 0},
+			volume:    6,
+		},
+		{
+			// Dominated and out-of-bounds points contribute nothing.
+			points:    [][]float64{{1, 3}, {2, 2}, {3, 1}, {1, 1}, {5, -1}},
+			reference: []float64{0, 0},
+			volume:    6,
+		},
+		{
+			points:    [][]float64{{2, 2, 2}},
+			reference: []float64{0, 0, 0},
+			volume:    8,
+		},
+		{
+			points:    [][]float64{{2, 1, 1}, {1, 2, 1}, {1, 1, 2}},
+			reference: []float64{0, 0, 0},
+			volume:    4,
+		},
+		{
+			points:    nil,
+			reference: []float64{0, 0, 0},
+			volume:    0,
+		},
+	}
+
+	for _, fixture := range fixtures {
+		volume := genetic.Hypervolume(fixture.points, fixture.reference)
+		if math.Abs(volume-fixture.volume) > 1e-9 {
+			t.Errorf("unexpected hypervolume of %v\nWanted %f\nGot    %f", fixture.points, fixture.volume, volume)
+		}
+	}
+}
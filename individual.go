@@ -0,0 +1,138 @@
+package genetic
+
+// Names of the operators recorded in Individual.Operators.
+const (
+	OperatorGenesis   = "genesis"
+	OperatorSeed      = "seed"
+	OperatorInjection = "injection"
+	OperatorCrossover = "crossover"
+	OperatorMutation  = "mutation"
+)
+
+// Individual describes the life history of a genome in a Population.
+type Individual struct {
+	// ID uniquely identifies the individual within its population. IDs are assigned
+	// in order of birth, starting from 1.
+	ID uint64
+
+	// BirthGeneration is the generation in which the individual was born. Individuals
+	// of the initial population are born in generation zero.
+	BirthGeneration int
+
+	// Age is the number of generations, or steady-state steps, the individual has survived.
+	Age int
+
+	// Parents are the IDs of the individual's parents. It is empty for individuals
+	// which were not bred from other individuals, such as the initial population.
+	Parents []uint64
+
+	// Operators names the operators which produced the individual, in the order they were applied.
+	Operators []string
+}
+
+// newIndividual assigns a new ID to an individual born in the next generation.
+func (population *Population[T]) newIndividual(parents []uint64, operators ...string) Individual {
+	population.lastID++
+	return Individual{
+		ID:              population.lastID,
+		BirthGeneration: population.generation,
+		Parents:         parents,
+		Operators:       operators,
+	}
+}
+
+// breed crosses over and mutates each mating pair, returning the children
+// along with the individuals describing them.
+func (population *Population[T]) breed(matingPairs [][2]int) ([]T, []Individual) {
+	childGenomes := make([]T, 0, len(matingPairs)*2)
+	childIndividuals := make([]Individual, 0, len(matingPairs)*2)
+
+	defaultOperators := []string{OperatorCrossover}
+	if population.Mutation != nil {
+		defaultOperators = append(defaultOperators, OperatorMutation)
+	}
+
+	for _, matingPair := range matingPairs {
+		crossover, mutation, operators := population.Crossover, population.Mutation, defaultOperators
+
+		chosen := -1
+		if population.Operators != nil {
+			chosen = population.Operators.choose()
+			operator := population.Operators.operators[chosen]
+			if operator.Crossover != nil {
+				crossover = operator.Crossover
+			}
+			if operator.Mutation != nil {
+				mutation = operator.Mutation
+			}
+			operators = []string{operator.Name}
+		}
+
+		offspring1, offspring2 := crossover(
+			population.genomes[matingPair[0]],
+			population.genomes[matingPair[1]],
+		)
+		if mutation != nil {
+			mutation(offspring1)
+			mutation(offspring2)
+		}
+		childGenomes = append(childGenomes, offspring1, offspring2)
+
+		parents := []uint64{
+			population.individuals[matingPair[0]].ID,
+			population.individuals[matingPair[1]].ID,
+		}
+		child1 := population.newIndividual(parents, operators...)
+		child2 := population.newIndividual(parents, operators...)
+		childIndividuals = append(childIndividuals, child1, child2)
+
+		if chosen >= 0 {
+			parentFitness := population.fitnesses[matingPair[0]]
+			if population.fitnesses[matingPair[1]] > parentFitness {
+				parentFitness = population.fitnesses[matingPair[1]]
+			}
+			population.Operators.expect(child1.ID, chosen, parentFitness)
+			population.Operators.expect(child2.ID, chosen, parentFitness)
+		}
+	}
+
+	return childGenomes, childIndividuals
+}
+
+// aged returns a copy of the given individuals, each one generation older.
+func aged(individuals []Individual) []Individual {
+	older := make([]Individual, len(individuals))
+	for i, individual := range individuals {
+		individual.Age++
+		older[i] = individual
+	}
+	return older
+}
+
+// Generation returns the number of generations, or steady-state steps, the population has evolved.
+func (population *Population[T]) Generation() int {
+	population.state.RLock()
+	defer population.state.RUnlock()
+
+	return population.publishedGeneration
+}
+
+// Individuals returns descriptions of every member of the population, in descending order of
+// fitness, such that the first Individual describes the genome returned by Best.
+func (population *Population[T]) Individuals() []Individual {
+	population.state.RLock()
+	defer population.state.RUnlock()
+
+	return cloneIndividuals(population.individuals)
+}
+
+// cloneIndividuals returns a deep copy of the given individuals.
+func cloneIndividuals(individuals []Individual) []Individual {
+	clones := make([]Individual, len(individuals))
+	for i, individual := range individuals {
+		individual.Parents = append([]uint64(nil), individual.Parents...)
+		individual.Operators = append([]string(nil), individual.Operators...)
+		clones[i] = individual
+	}
+	return clones
+}
@@ -0,0 +1,52 @@
+package genetic_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPopulationIndividuals(t *testing.T) {
+	population := newTestPopulation(geneFitness, countingGenomes(10)...)
+	// Children are always less fit than their parents, so the elite never changes.
+	population.Crossover = func(male, female []int) ([]int, []int) { return []int{0}, []int{0} }
+	population.Mutation = func([]int) {}
+
+	generations := 3
+	for i := 0; i < generations; i++ {
+		population.EvolveOnce(1)
+	}
+
+	if population.Generation() != generations {
+		t.Errorf("expected generation %d; got %d", generations, population.Generation())
+	}
+
+	individuals := population.Individuals()
+	elite := individuals[0]
+	if elite.Age != generations || elite.BirthGeneration != 0 {
+		t.Errorf("expected elite to be %d generations old, born in generation 0; got %+v", generations, elite)
+	}
+	if len(elite.Parents) != 0 || fmt.Sprint(elite.Operators) != "[genesis]" {
+		t.Errorf("expected elite to be created by genesis; got %+v", elite)
+	}
+
+	child := individuals[1]
+	if child.Age != 0 || child.BirthGeneration != generations {
+		t.Errorf("expected child to be born in generation %d; got %+v", generations, child)
+	}
+	if len(child.Parents) != 2 || fmt.Sprint(child.Operators) != "[crossover mutation]" {
+		t.Errorf("expected child to be bred by crossover and mutation from two parents; got %+v", child)
+	}
+
+	seen := make(map[uint64]bool)
+	for _, individual := range individuals {
+		if seen[individual.ID] {
+			t.Errorf("duplicate individual ID %d", individual.ID)
+		}
+		seen[individual.ID] = true
+		for _, parent := range individual.Parents {
+			if parent >= individual.ID {
+				t.Errorf("expected parent ID %d to be assigned before child ID %d", parent, individual.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package genetic
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Default sample counts used by Landscape when its counts are zero.
+const (
+	DefaultLandscapeSamples    = 100
+	DefaultLandscapeWalkLength = 100
+	DefaultLandscapeNeighbours = 10
+)
+
+// Landscape describes a problem whose fitness landscape is to be analyzed before evolving it, to
+// help choose suitable operators. Neighbouring genomes are defined by the MutationFunc: the
+// neighbours of a genome are the genomes it can be mutated into.
+type Landscape[T any] struct {
+	// Genesis creates random genomes to sample the landscape.
+	Genesis GenesisFunc[T]
+
+	// Fitness computes the fitnesses of sampled genomes. It is called on batches of unrelated
+	// genomes, none of which are marked as evaluated.
+	Fitness FitnessFunc[T]
+
+	// Mutation alters a genome to produce one of its neighbours.
+	Mutation MutationFunc[T]
+
+	// Clone returns a copy of a genome, which can be mutated without altering the original.
+	Clone func(T) T
+
+	// Distance optionally measures the distance between genomes, used to compute the
+	// fitness distance correlation. If nil, it is not computed.
+	Distance DistanceFunc[T]
+
+	// Optimum is optionally a known global optimum, to which distances are measured when computing
+	// the fitness distance correlation. If nil, the fittest sampled genome stands in for it, which
+	// weakens the correlation.
+	Optimum *T
+
+	// Samples is the number of random genomes sampled. Defaults to DefaultLandscapeSamples.
+	Samples int
+
+	// WalkLength is the number of steps taken by the random walk. Defaults to DefaultLandscapeWalkLength.
+	WalkLength int
+
+	// Neighbours is the number of neighbours sampled around each random genome.
+	// Defaults to DefaultLandscapeNeighbours.
+	Neighbours int
+}
+
+// LandscapeReport summarizes the features of a fitness landscape.
+type LandscapeReport struct {
+	// FitnessDistanceCorrelation is the correlation between the fitnesses of sampled genomes and
+	// their distances to the global optimum, or to the fittest sample if it is unknown. Values
+	// near -1 indicate that fitness rises steadily towards the optimum, so the problem is easy; values
+	// near zero indicate a difficult problem; and positive values indicate a deceptive problem, in
+	// which fitness leads away from the optimum. It is NaN if the Landscape has no Distance, or if
+	// the sampled fitnesses or distances do not vary, as on a flat landscape.
+	FitnessDistanceCorrelation float64
+
+	// Autocorrelation is the correlation between the fitnesses of consecutive genomes of a random
+	// walk, in which each step mutates the previous genome. High values indicate a smooth landscape.
+	// It is NaN if fitness never changed along the walk, as on a flat landscape.
+	Autocorrelation float64
+
+	// CorrelationLength is the number of mutations after which fitness becomes effectively
+	// uncorrelated, computed as -1/ln|Autocorrelation|. Short correlation lengths indicate a rugged
+	// landscape, which favours larger populations and more disruptive operators.
+	CorrelationLength float64
+
+	// Neutrality is the fraction of mutations which do not change fitness. Highly neutral
+	// landscapes have plateaus which selection cannot climb, favouring larger mutations.
+	Neutrality float64
+
+	// LocalOptimaDensity is the fraction of sampled genomes which are not improved upon by any of
+	// their sampled neighbours. High densities indicate a multimodal landscape, favouring niching
+	// and restarts.
+	LocalOptimaDensity float64
+
+	// Evaluations is the total number of fitness evaluations performed by the analysis.
+	Evaluations int
+
+	// distanceMeasured is true if the Landscape had a Distance, so that a NaN
+	// FitnessDistanceCorrelation means that it is undefined rather than not computed.
+	distanceMeasured bool
+}
+
+// String formats the report along with a brief interpretation of each feature.
+func (report *LandscapeReport) String() string {
+	var b strings.Builder
+
+	fdc := "not computed"
+	if math.IsNaN(report.FitnessDistanceCorrelation) {
+		if report.distanceMeasured {
+			fdc = "undefined, no variance"
+		}
+	} else {
+		switch {
+		case report.FitnessDistanceCorrelation <= -0.15:
+			fdc = "easy"
+		case report.FitnessDistanceCorrelation < 0.15:
+			fdc = "difficult"
+		default:
+			fdc = "deceptive"
+		}
+	}
+
+	ruggedness := "smooth"
+	if math.IsNaN(report.Autocorrelation) {
+		ruggedness = "flat"
+	} else if report.CorrelationLength < 1 {
+		ruggedness = "rugged"
+	} else if report.CorrelationLength < 10 {
+		ruggedness = "moderately rugged"
+	}
+
+	fmt.Fprintf(&b, "fitness distance correlation: %.3f (%s)\n", report.FitnessDistanceCorrelation, fdc)
+	fmt.Fprintf(&b, "autocorrelation: %.3f, correlation length: %.2f (%s)\n", report.Autocorrelation, report.CorrelationLength, ruggedness)
+	fmt.Fprintf(&b, "neutrality: %.1f%%\n", report.Neutrality*100)
+	fmt.Fprintf(&b, "local optima density: %.1f%%\n", report.LocalOptimaDensity*100)
+	fmt.Fprintf(&b, "evaluations: %d\n", report.Evaluations)
+	return b.String()
+}
+
+// Analyze samples the fitness landscape and reports its features.
+func (landscape *Landscape[T]) Analyze() *LandscapeReport {
+	if landscape.Genesis == nil {
+		panic("expected to receive GenesisFunc")
+	} else if landscape.Fitness == nil {
+		panic("expected to receive FitnessFunc")
+	} else if landscape.Mutation == nil {
+		panic("expected to receive MutationFunc")
+	} else if landscape.Clone == nil {
+		panic("expected to receive clone function")
+	}
+
+	samples := landscape.Samples
+	if samples <= 0 {
+		samples = DefaultLandscapeSamples
+	}
+	walkLength := landscape.WalkLength
+	if walkLength <= 0 {
+		walkLength = DefaultLandscapeWalkLength
+	}
+	neighbours := landscape.Neighbours
+	if neighbours <= 0 {
+		neighbours = DefaultLandscapeNeighbours
+	}
+
+	report := &LandscapeReport{distanceMeasured: landscape.Distance != nil}
+
+	genomes := make([]T, samples)
+	for i := range genomes {
+		genomes[i] = landscape.Genesis()
+	}
+	fitnesses := landscape.evaluate(genomes, report)
+
+	report.FitnessDistanceCorrelation = landscape.fitnessDistanceCorrelation(genomes, fitnesses)
+	report.Autocorrelation = landscape.walkAutocorrelation(walkLength, report)
+	report.CorrelationLength = correlationLength(report.Autocorrelation)
+	report.Neutrality, report.LocalOptimaDensity = landscape.neighbourhoods(genomes, fitnesses, neighbours, report)
+
+	return report
+}
+
+// evaluate computes the fitnesses of the given genomes, counting the evaluations in the report.
+func (landscape *Landscape[T]) evaluate(genomes []T, report *LandscapeReport) []int {
+	fitnesses := unknownFitnesses(len(genomes))
+	landscape.Fitness(genomes, fitnesses, make([]bool, len(genomes)))
+	report.Evaluations += len(genomes)
+	return fitnesses
+}
+
+// fitnessDistanceCorrelation correlates the fitnesses of the sampled genomes with their
+// distances to the optimum. If the optimum is unknown, the fittest sample is used in its
+// place, and is itself excluded.
+func (landscape *Landscape[T]) fitnessDistanceCorrelation(genomes []T, fitnesses []int) float64 {
+	if landscape.Distance == nil {
+		return math.NaN()
+	}
+
+	best := -1
+	var optimum T
+	if landscape.Optimum != nil {
+		optimum = *landscape.Optimum
+	} else {
+		best = sortedIndexes(sortDescending, fitnesses)[0]
+		optimum = genomes[best]
+	}
+
+	xs := make([]float64, 0, len(genomes))
+	ys := make([]float64, 0, len(genomes))
+	for i, genome := range genomes {
+		if i != best {
+			xs = append(xs, float64(fitnesses[i]))
+			ys = append(ys, landscape.Distance(genome, optimum))
+		}
+	}
+	return pearsonCorrelation(xs, ys)
+}
+
+// walkAutocorrelation takes a random walk of the given length, and returns the correlation
+// between the fitnesses of consecutive steps.
+func (landscape *Landscape[T]) walkAutocorrelation(walkLength int, report *LandscapeReport) float64 {
+	walk := make([]T, walkLength+1)
+	walk[0] = landscape.Genesis()
+	for i := 1; i < len(walk); i++ {
+		walk[i] = landscape.Clone(walk[i-1])
+		landscape.Mutation(walk[i])
+	}
+
+	series := landscape.evaluate(walk, report)
+	xs := make([]float64, walkLength)
+	ys := make([]float64, walkLength)
+	for i := 0; i < walkLength; i++ {
+		xs[i] = float64(series[i])
+		ys[i] = float64(series[i+1])
+	}
+	return pearsonCorrelation(xs, ys)
+}
+
+// neighbourhoods samples neighbours of each of the given genomes, and returns the fraction of
+// neutral mutations and the fraction of genomes with no fitter neighbour.
+func (landscape *Landscape[T]) neighbourhoods(genomes []T, fitnesses []int, neighbours int, report *LandscapeReport) (float64, float64) {
+	mutants := make([]T, 0, len(genomes)*neighbours)
+	for _, genome := range genomes {
+		for j := 0; j < neighbours; j++ {
+			mutant := landscape.Clone(genome)
+			landscape.Mutation(mutant)
+			mutants = append(mutants, mutant)
+		}
+	}
+	mutantFitnesses := landscape.evaluate(mutants, report)
+
+	neutral, optima := 0, 0
+	for i, fitness := range fitnesses {
+		improved := false
+		for _, mutantFitness := range mutantFitnesses[i*neighbours : (i+1)*neighbours] {
+			if mutantFitness == fitness {
+				neutral++
+			} else if mutantFitness > fitness {
+				improved = true
+			}
+		}
+		if !improved {
+			optima++
+		}
+	}
+
+	return float64(neutral) / float64(len(mutants)), float64(optima) / float64(len(genomes))
+}
+
+// correlationLength converts a random walk autocorrelation into a correlation length.
+func correlationLength(autocorrelation float64) float64 {
+	rho := math.Abs(autocorrelation)
+	if math.IsNaN(rho) || rho == 0 {
+		return 0
+	} else if rho >= 1 {
+		return math.Inf(1)
+	}
+	return -1 / math.Log(rho)
+}
+
+// pearsonCorrelation returns the Pearson correlation coefficient of xs and ys,
+// or NaN if either has zero variance.
+func pearsonCorrelation(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	meanX, meanY := 0.0, 0.0
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	covariance, varianceX, varianceY := 0.0, 0.0, 0.0
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+
+	if varianceX == 0 || varianceY == 0 {
+		return math.NaN()
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
@@ -0,0 +1,96 @@
+package genetic_test
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func newOneMaxLandscape(length int) *genetic.Landscape[[]bool] {
+	return &genetic.Landscape[[]bool]{
+		Genesis: func() []bool {
+			genome := make([]bool, length)
+			for i := range genome {
+				genome[i] = rand.Intn(2) == 1
+			}
+			return genome
+		},
+		Fitness: genetic.StaticFitnessFunc(func(genome []bool) int {
+			ones := 0
+			for _, bit := range genome {
+				if bit {
+					ones++
+				}
+			}
+			return ones
+		}),
+		Mutation: func(genome []bool) {
+			i := rand.Intn(len(genome))
+			genome[i] = !genome[i]
+		},
+		Clone: func(genome []bool) []bool {
+			return append([]bool(nil), genome...)
+		},
+		Distance: genetic.HammingDistance[bool],
+	}
+}
+
+func TestLandscapeAnalyze(t *testing.T) {
+	landscape := newOneMaxLandscape(30)
+	landscape.Samples = 50
+	landscape.WalkLength = 200
+	landscape.Neighbours = 30
+
+	optimum := make([]bool, 30)
+	for i := range optimum {
+		optimum[i] = true
+	}
+	landscape.Optimum = &optimum
+
+	report := landscape.Analyze()
+
+	if report.FitnessDistanceCorrelation > -0.99 {
+		t.Errorf("expected OneMax to have a strongly negative fitness distance correlation; got %.3f", report.FitnessDistanceCorrelation)
+	}
+	if report.Autocorrelation < 0.7 {
+		t.Errorf("expected OneMax to have a smooth landscape; got autocorrelation %.3f", report.Autocorrelation)
+	}
+	if report.Neutrality != 0 {
+		t.Errorf("expected every bit flip to change OneMax fitness; got neutrality %.3f", report.Neutrality)
+	}
+	if report.LocalOptimaDensity > 0.1 {
+		t.Errorf("expected OneMax to have almost no local optima; got density %.3f", report.LocalOptimaDensity)
+	}
+	if expected := 50 + 201 + 50*30; report.Evaluations != expected {
+		t.Errorf("expected %d evaluations; got %d", expected, report.Evaluations)
+	}
+	if !strings.Contains(report.String(), "(easy)") {
+		t.Errorf("expected report to describe OneMax as easy; got:\n%s", report)
+	}
+}
+
+func TestLandscapeAnalyzeNeutral(t *testing.T) {
+	landscape := newOneMaxLandscape(10)
+	landscape.Fitness = genetic.StaticFitnessFunc(func([]bool) int { return 1 })
+	landscape.Distance = nil
+
+	report := landscape.Analyze()
+	if report.Neutrality != 1 || report.LocalOptimaDensity != 1 {
+		t.Errorf("expected flat landscape to be entirely neutral; got %+v", report)
+	}
+	if !strings.Contains(report.String(), "not computed") {
+		t.Errorf("expected report to omit fitness distance correlation without a Distance; got:\n%s", report)
+	}
+	if !strings.Contains(report.String(), "(flat)") || strings.Contains(report.String(), "rugged") {
+		t.Errorf("expected report to describe a flat landscape as flat; got:\n%s", report)
+	}
+
+	landscape.Distance = genetic.HammingDistance[bool]
+	report = landscape.Analyze()
+	if !math.IsNaN(report.FitnessDistanceCorrelation) || !strings.Contains(report.String(), "(undefined, no variance)") {
+		t.Errorf("expected fitness distance correlation of a flat landscape to be undefined; got:\n%s", report)
+	}
+}
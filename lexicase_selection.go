@@ -0,0 +1,247 @@
+package genetic
+
+import (
+	"sort"
+	"sync"
+)
+
+// CaseFitnessFunc calculates the fitness of a genome on each of a fixed set of test cases,
+// rather than aggregating them into a single score. Higher scores are better. Every call
+// must return a slice of the same length, with scores for the same cases in the same order.
+type CaseFitnessFunc[T any] func(genome T) []int
+
+// lexicaseWinner filters the candidates down by walking through the test cases in a random order,
+// keeping only those candidates which score within epsilons[c] of the best score on case c.
+// It returns a random survivor once every case has been considered, or when only one remains.
+func lexicaseWinner(caseScores [][]int, epsilons []int, candidates []int) int {
+	survivors := make([]int, len(candidates))
+	copy(survivors, candidates)
+
+	caseCount := len(caseScores[candidates[0]])
+	for _, c := range randPerm(caseCount) {
+		if len(survivors) == 1 {
+			break
+		}
+
+		best := caseScores[survivors[0]][c]
+		for _, i := range survivors[1:] {
+			if caseScores[i][c] > best {
+				best = caseScores[i][c]
+			}
+		}
+
+		threshold := best
+		if epsilons != nil {
+			threshold -= epsilons[c]
+		}
+
+		filtered := survivors[:0]
+		for _, i := range survivors {
+			if caseScores[i][c] >= threshold {
+				filtered = append(filtered, i)
+			}
+		}
+		survivors = filtered
+	}
+
+	return survivors[randInt(len(survivors))]
+}
+
+// medianAbsoluteDeviations computes the median absolute deviation of the population's scores on
+// each test case. These are used as the per-case tolerances for epsilon-lexicase selection.
+func medianAbsoluteDeviations(caseScores [][]int) []int {
+	caseCount := len(caseScores[0])
+	epsilons := make([]int, caseCount)
+	column := make([]int, len(caseScores))
+
+	for c := 0; c < caseCount; c++ {
+		for i, scores := range caseScores {
+			column[i] = scores[c]
+		}
+		median := medianInt(column)
+
+		for i, scores := range caseScores {
+			deviation := scores[c] - median
+			if deviation < 0 {
+				deviation = -deviation
+			}
+			column[i] = deviation
+		}
+		epsilons[c] = medianInt(column)
+	}
+
+	return epsilons
+}
+
+// medianInt returns the lower median of the given integers. It sorts ints in place.
+func medianInt(ints []int) int {
+	sort.Ints(ints)
+	return ints[(len(ints)-1)/2]
+}
+
+// Lexicase implements lexicase and epsilon-lexicase selection, which select parents by their
+// performance on individual test cases rather than by aggregate fitness. Each genome is scored
+// on every test case only once: the case scores computed by its FitnessFunc are kept, keyed by
+// genome, and reused by its selection functions. To use lexicase selection, use both the
+// Lexicase's FitnessFunc and one of its selection functions:
+//
+//	lexicase := genetic.NewLexicase(cases, func(genome []int) string { return fmt.Sprint(genome) })
+//	population := genetic.NewPopulation(size, generate, crossover, lexicase.FitnessFunc(), lexicase.Selection(), mutation)
+//
+// Case scores are discarded once their genome has left the population. A Lexicase is safe for
+// concurrent use.
+type Lexicase[T any, K comparable] struct {
+	cases CaseFitnessFunc[T]
+	key   func(T) K
+
+	mutex  sync.Mutex
+	scores map[K][]int
+}
+
+// NewLexicase creates a Lexicase which scores genomes with the given CaseFitnessFunc. Genomes are
+// identified by a comparable key computed from each genome, such as a string encoding of its DNA.
+// The key function must return equal keys only for genomes which have equal case scores.
+func NewLexicase[T any, K comparable](cases CaseFitnessFunc[T], key func(T) K) *Lexicase[T, K] {
+	if cases == nil {
+		panic("expected to receive CaseFitnessFunc")
+	} else if key == nil {
+		panic("expected to receive key function")
+	}
+
+	return &Lexicase[T, K]{
+		cases:  cases,
+		key:    key,
+		scores: make(map[K][]int),
+	}
+}
+
+// CaseScores returns the scores of the given genome on every test case, computing them only if
+// the scores of a genome with the same key are not already known.
+func (lexicase *Lexicase[T, K]) CaseScores(genome T) []int {
+	key := lexicase.key(genome)
+
+	lexicase.mutex.Lock()
+	scores, ok := lexicase.scores[key]
+	lexicase.mutex.Unlock()
+	if ok {
+		return scores
+	}
+
+	scores = lexicase.cases(genome)
+
+	lexicase.mutex.Lock()
+	lexicase.scores[key] = scores
+	lexicase.mutex.Unlock()
+	return scores
+}
+
+// FitnessFunc returns a static FitnessFunc whose aggregate fitness is the sum of a genome's
+// scores on every test case, keeping the case scores for use in selection.
+func (lexicase *Lexicase[T, K]) FitnessFunc() FitnessFunc[T] {
+	return StaticFitnessFunc(func(genome T) int {
+		sum := 0
+		for _, score := range lexicase.CaseScores(genome) {
+			sum += score
+		}
+		return sum
+	})
+}
+
+// Selection returns a SelectionFunc which implements lexicase selection. To choose each mate,
+// the test cases are shuffled, and the candidates are filtered case by case, keeping only those
+// with the best score on the current case, until a single candidate remains or every case has
+// been used. Ties are broken at random. Genomes which excel on a few cases can thus be selected
+// even if their aggregate fitness is poor, which maintains diversity on problems where different
+// cases reward different behaviour. Genomes cannot mate with themselves.
+func (lexicase *Lexicase[T, K]) Selection() SelectionFunc[T] {
+	return lexicase.selection(false)
+}
+
+// EpsilonSelection returns a SelectionFunc which implements epsilon-lexicase selection, a variant
+// of lexicase selection suited to cases with many distinct scores. On each case, candidates are
+// kept if they score within epsilon of the best, where epsilon is the median absolute deviation
+// of the population's scores on that case.
+func (lexicase *Lexicase[T, K]) EpsilonSelection() SelectionFunc[T] {
+	return lexicase.selection(true)
+}
+
+// PairSelection returns a PairSelectionFunc which selects a single pair of mates by lexicase
+// selection, for use in steady-state evolution.
+func (lexicase *Lexicase[T, K]) PairSelection() PairSelectionFunc[T] {
+	return lexicase.pairSelection(false)
+}
+
+// EpsilonPairSelection returns a PairSelectionFunc which selects a single pair of mates by
+// epsilon-lexicase selection, for use in steady-state evolution.
+func (lexicase *Lexicase[T, K]) EpsilonPairSelection() PairSelectionFunc[T] {
+	return lexicase.pairSelection(true)
+}
+
+func (lexicase *Lexicase[T, K]) pairSelection(useEpsilon bool) PairSelectionFunc[T] {
+	return func(genomes []T, _ []int) [2]int {
+		caseScores, epsilons := lexicase.populationScores(genomes, useEpsilon)
+		return lexicasePair(caseScores, epsilons)
+	}
+}
+
+func (lexicase *Lexicase[T, K]) selection(useEpsilon bool) SelectionFunc[T] {
+	return func(genomes []T, _ []int) [][2]int {
+		caseScores, epsilons := lexicase.populationScores(genomes, useEpsilon)
+
+		matingPairs := make([][2]int, 0, len(genomes)/2)
+		for len(matingPairs)*2 < len(genomes) {
+			matingPairs = append(matingPairs, lexicasePair(caseScores, epsilons))
+		}
+		return matingPairs
+	}
+}
+
+// populationScores returns the case scores of every genome, along with the epsilon tolerances
+// of each case if useEpsilon is true. Since genomes is the whole population, case scores of
+// genomes which are no longer members are discarded.
+func (lexicase *Lexicase[T, K]) populationScores(genomes []T, useEpsilon bool) ([][]int, []int) {
+	caseScores := make([][]int, len(genomes))
+	keys := make(map[K]bool, len(genomes))
+	for i, genome := range genomes {
+		caseScores[i] = lexicase.CaseScores(genome)
+		if len(caseScores[i]) != len(caseScores[0]) {
+			panic("CaseFitnessFunc returned mismatching numbers of test case scores")
+		}
+		keys[lexicase.key(genome)] = true
+	}
+
+	lexicase.mutex.Lock()
+	for key := range lexicase.scores {
+		if !keys[key] {
+			delete(lexicase.scores, key)
+		}
+	}
+	lexicase.mutex.Unlock()
+
+	var epsilons []int
+	if useEpsilon {
+		epsilons = medianAbsoluteDeviations(caseScores)
+	}
+	return caseScores, epsilons
+}
+
+// lexicasePair selects a pair of distinct mates by lexicase selection.
+func lexicasePair(caseScores [][]int, epsilons []int) [2]int {
+	everyone := make([]int, len(caseScores))
+	for i := range everyone {
+		everyone[i] = i
+	}
+	mate1Index := lexicaseWinner(caseScores, epsilons, everyone)
+
+	// The second mate is drawn from everyone except the first, so that a genome
+	// which dominates every case cannot be paired with itself.
+	others := make([]int, 0, len(caseScores)-1)
+	for _, i := range everyone {
+		if i != mate1Index {
+			others = append(others, i)
+		}
+	}
+	mate2Index := lexicaseWinner(caseScores, epsilons, others)
+
+	return [2]int{mate1Index, mate2Index}
+}
@@ -0,0 +1,134 @@
+package genetic
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLexicaseSelection(t *testing.T) {
+	// Each genome is its own vector of case scores. "generalist" has the best aggregate
+	// fitness, but the specialists each win a case outright.
+	genomes := [][]int{
+		{9, 0, 0},
+		{0, 9, 0},
+		{0, 0, 9},
+		{5, 5, 5},
+		{1, 1, 1},
+		{0, 0, 0},
+	}
+	lexicase := NewLexicase(func(genome []int) []int { return genome }, genomeKey)
+
+	selection := lexicase.Selection()
+	fitnesses := unknownFitnesses(len(genomes))
+	lexicase.FitnessFunc()(genomes, fitnesses, make([]bool, len(genomes)))
+
+	selectedCounts := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		matingPairs := selection(genomes, fitnesses)
+		if len(matingPairs)*2 < len(genomes) {
+			t.Fatalf("too few mating pairs: %d", len(matingPairs))
+		}
+
+		for _, pair := range matingPairs {
+			if pair[0] == pair[1] {
+				t.Fatalf("genome %v mated with itself", genomes[pair[0]])
+			}
+			selectedCounts[fmt.Sprint(genomes[pair[0]])]++
+		}
+	}
+
+	for _, specialist := range genomes[:3] {
+		if selectedCounts[fmt.Sprint(specialist)] == 0 {
+			t.Errorf("expected specialist %v to be selected", specialist)
+		}
+	}
+	if n := selectedCounts[fmt.Sprint(genomes[3])]; n != 0 {
+		t.Errorf("expected generalist to never win lexicase selection as first mate; won %d times", n)
+	}
+}
+
+func TestLexicaseCaseScoresComputedOnce(t *testing.T) {
+	caseCalls := 0
+	cases := func(genome []int) []int {
+		caseCalls++
+		return genome
+	}
+	lexicase := NewLexicase(cases, genomeKey)
+
+	genomes := [][]int{{3, 0}, {0, 3}, {1, 1}, {2, 2}}
+	fitnesses := unknownFitnesses(len(genomes))
+	lexicase.FitnessFunc()(genomes, fitnesses, make([]bool, len(genomes)))
+	if fmt.Sprint(fitnesses) != "[3 3 2 4]" {
+		t.Fatalf("unexpected fitnesses\nWanted [3 3 2 4]\nGot    %v", fitnesses)
+	}
+
+	for i := 0; i < 10; i++ {
+		lexicase.Selection()(genomes, fitnesses)
+		lexicase.EpsilonSelection()(genomes, fitnesses)
+	}
+	if caseCalls != len(genomes) {
+		t.Errorf("expected each genome's cases to be evaluated once; got %d calls for %d genomes", caseCalls, len(genomes))
+	}
+
+	// Scores of genomes which left the population are forgotten.
+	lexicase.Selection()(genomes[:2], fitnesses[:2])
+	lexicase.Selection()(genomes, fitnesses)
+	if caseCalls != len(genomes)+2 {
+		t.Errorf("expected departed genomes to be re-evaluated; got %d calls", caseCalls)
+	}
+}
+
+func genomeKey(genome []int) string {
+	return fmt.Sprint(genome)
+}
+
+func TestMedianAbsoluteDeviations(t *testing.T) {
+	caseScores := [][]int{
+		{1, 10},
+		{2, 10},
+		{3, 10},
+		{4, 10},
+		{9, 10},
+	}
+
+	epsilons := medianAbsoluteDeviations(caseScores)
+	if fmt.Sprint(epsilons) != "[1 0]" {
+		t.Errorf("unexpected median absolute deviations\nWanted [1 0]\nGot    %v", epsilons)
+	}
+}
+
+func TestEpsilonLexicaseSelection(t *testing.T) {
+	// Scores on the single case are close together; with epsilon tolerance, more than
+	// just the single best genome should be selected as the first mate.
+	genomes := [][]int{{100}, {99}, {98}, {50}, {0}}
+	selection := NewLexicase(func(genome []int) []int { return genome }, genomeKey).EpsilonSelection()
+
+	winners := make(map[int]bool)
+	for i := 0; i < 200; i++ {
+		for _, pair := range selection(genomes, nil) {
+			winners[genomes[pair[0]][0]] = true
+		}
+	}
+
+	if !winners[100] || !winners[99] {
+		t.Errorf("expected near-best genomes to be selected; got %v", winners)
+	}
+	if winners[0] {
+		t.Errorf("expected worst genome never to be selected first")
+	}
+}
+
+func TestLexicasePairSelection(t *testing.T) {
+	genomes := [][]int{{9, 0}, {0, 9}, {5, 5}, {0, 0}}
+	selection := NewLexicase(func(genome []int) []int { return genome }, genomeKey).PairSelection()
+
+	for i := 0; i < 100; i++ {
+		pair := selection(genomes, nil)
+		if pair[0] == pair[1] {
+			t.Fatalf("genome %v mated with itself", genomes[pair[0]])
+		}
+		if pair[0] > 1 {
+			t.Fatalf("expected a specialist to win as first mate; got %v", genomes[pair[0]])
+		}
+	}
+}
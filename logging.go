@@ -0,0 +1,58 @@
+package genetic
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// log emits a log event to the population's Logger, if it has one. Every event is
+// annotated with the population's current generation.
+func (population *Population[T]) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if population.Logger == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if !population.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs = append([]slog.Attr{slog.Int("generation", population.generation)}, attrs...)
+	population.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logGeneration logs the completion of a generation, and any improvement
+// upon the best fitness seen so far.
+func (population *Population[T]) logGeneration(stats GenerationStats) {
+	if stats.BestFitness > population.bestFitness {
+		population.log(slog.LevelInfo, "new best fitness",
+			slog.Int("fitness", stats.BestFitness),
+			slog.Int("previousFitness", population.bestFitness),
+		)
+		population.bestFitness = stats.BestFitness
+	}
+
+	population.log(slog.LevelDebug, "generation complete",
+		slog.Int("size", stats.Size),
+		slog.Int("bestFitness", stats.BestFitness),
+		slog.Float64("meanFitness", stats.MeanFitness),
+		slog.Int("evaluations", stats.Evaluations),
+		slog.Duration("evaluationTime", stats.EvaluationTime),
+		slog.Duration("duration", stats.Duration),
+	)
+}
+
+// logPanic logs a panic raised during evolution, typically by one of the population's
+// operators, and then continues panicking. It must be deferred.
+func (population *Population[T]) logPanic() {
+	if r := recover(); r != nil {
+		population.logPanicValue(r)
+		panic(r)
+	}
+}
+
+// logPanicValue logs a recovered panic value.
+func (population *Population[T]) logPanicValue(r any) {
+	population.log(slog.LevelError, "evolution panicked", slog.String("error", fmt.Sprint(r)))
+}
@@ -0,0 +1,107 @@
+package genetic_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+// decodeLogEvents parses the output of a slog.JSONHandler.
+func decodeLogEvents(t *testing.T, output *bytes.Buffer) []map[string]any {
+	var events []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var event map[string]any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("failed to decode log event %q: %s", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestPopulationLogger(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	generation := 0
+	population.Fitness = genetic.StaticFitnessFunc(func([]int) int { return generation })
+
+	var output bytes.Buffer
+	population.Logger = slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// The initial population has a fitness of 1.
+	for generation = 2; generation <= 4; generation++ {
+		population.EvolveOnce(0)
+	}
+	population.Restart(genetic.RandomRestart(2))
+
+	messages := make(map[string]int)
+	for _, event := range decodeLogEvents(t, &output) {
+		messages[event["msg"].(string)]++
+		if _, ok := event["generation"]; !ok {
+			t.Errorf("expected every event to include the generation; got %v", event)
+		}
+	}
+
+	if messages["generation complete"] != 3 {
+		t.Errorf("expected 3 generation events; got %d", messages["generation complete"])
+	}
+	if messages["new best fitness"] != 3 {
+		t.Errorf("expected 3 new best events; got %d", messages["new best fitness"])
+	}
+	if messages["population restarted"] != 1 {
+		t.Errorf("expected 1 restart event; got %d", messages["population restarted"])
+	}
+}
+
+func TestPopulationLoggerQuietByDefault(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+
+	var output bytes.Buffer
+	population.Logger = slog.New(slog.NewJSONHandler(&output, nil))
+	population.EvolveOnce(1)
+
+	if output.Len() != 0 {
+		t.Errorf("expected generation events to be hidden at the default level; got %s", output.String())
+	}
+}
+
+func TestPopulationLoggerPanic(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	population.Crossover = func(male, female []int) ([]int, []int) {
+		panic("crossover failed")
+	}
+
+	var output bytes.Buffer
+	population.Logger = slog.New(slog.NewJSONHandler(&output, nil))
+
+	defer func() {
+		if r := recover(); r != "crossover failed" {
+			t.Errorf("expected panic to propagate; got %v", r)
+		}
+
+		events := decodeLogEvents(t, &output)
+		if len(events) != 1 || events[0]["level"] != "ERROR" || events[0]["error"] != "crossover failed" {
+			t.Errorf("expected panic to be logged as an error; got %v", events)
+		}
+	}()
+	population.EvolveOnce(1)
+}
+
+func TestPopulationLoggerInject(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+
+	var output bytes.Buffer
+	population.Logger = slog.New(slog.NewJSONHandler(&output, nil))
+	population.Inject([]int{1}, []int{2}, []int{3})
+
+	events := decodeLogEvents(t, &output)
+	if len(events) != 1 || events[0]["msg"] != "genomes injected" || events[0]["count"] != 3.0 {
+		t.Errorf("expected injection to be logged with its count; got %v", events)
+	}
+}
@@ -42,3 +42,12 @@ func computeProportions(ints []int) []float64 {
 
 	return proportions
 }
+
+// meanFitness returns the arithmetic mean of the given fitnesses.
+func meanFitness(fitnesses []int) float64 {
+	sum := 0.0
+	for _, fitness := range fitnesses {
+		sum += float64(fitness)
+	}
+	return sum / float64(len(fitnesses))
+}
@@ -0,0 +1,171 @@
+package genetic
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the evaluation latency histogram
+// buckets used by NewMetrics when no buckets are given. They span fitness evaluations taking
+// from a microsecond to ten seconds.
+var DefaultLatencyBuckets = []float64{1e-6, 1e-5, 1e-4, 1e-3, 0.01, 0.1, 1, 10}
+
+// Metrics is an observer which exposes the progress of a Population as metrics in the Prometheus
+// text exposition format. It is also an http.Handler, serving the metrics to a Prometheus scraper.
+// To expose a population's metrics, add the Metrics' Observe method to the population's Observers,
+// and serve it over HTTP:
+//
+//	metrics := genetic.NewMetrics[MyGenome]("knapsack")
+//	population.Observers = append(population.Observers, metrics.Observe)
+//	http.Handle("/metrics", metrics)
+//
+// Metrics is safe for concurrent use.
+type Metrics[T any] struct {
+	mutex     sync.Mutex
+	namespace string
+
+	generation       int
+	size             int
+	bestFitness      int
+	meanFitness      float64
+	diversity        float64
+	evaluationsTotal int
+	restartsTotal    int
+
+	latencyBuckets []float64
+	latencyCounts  []int
+	latencySum     float64
+	latencyCount   int
+
+	// Diversity optionally measures the diversity of the population after each generation.
+	// If nil, no diversity metric is exposed.
+	Diversity func(population *Population[T]) float64
+}
+
+// NewMetrics initializes Metrics whose names are prefixed by the given namespace, such as
+// "genetic". The evaluation latency histogram uses the given bucket upper bounds, in seconds,
+// or DefaultLatencyBuckets if none are given.
+func NewMetrics[T any](namespace string, buckets ...float64) *Metrics[T] {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	latencyBuckets := make([]float64, len(buckets))
+	copy(latencyBuckets, buckets)
+	sort.Float64s(latencyBuckets)
+
+	return &Metrics[T]{
+		namespace:      namespace,
+		diversity:      math.NaN(),
+		latencyBuckets: latencyBuckets,
+		latencyCounts:  make([]int, len(latencyBuckets)),
+	}
+}
+
+// Observe updates the metrics from a generation of the given population. Its signature matches
+// ObserverFunc.
+//
+// Each observation in the evaluation latency histogram is the measured duration of one call to
+// the population's FitnessFunc, as given by GenerationStats.EvaluationTimes. A call evaluates the
+// whole generation of children during EvolveOnce, and the two children of a single step during
+// steady-state or asynchronous evolution.
+func (metrics *Metrics[T]) Observe(population *Population[T], stats GenerationStats) {
+	diversity := math.NaN()
+	if metrics.Diversity != nil {
+		diversity = metrics.Diversity(population)
+	}
+	restarts := len(population.Restarts())
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.generation = stats.Generation
+	metrics.size = stats.Size
+	metrics.bestFitness = stats.BestFitness
+	metrics.meanFitness = stats.MeanFitness
+	metrics.diversity = diversity
+	metrics.evaluationsTotal = stats.TotalEvaluations
+	metrics.restartsTotal = restarts
+
+	for _, evaluationTime := range stats.EvaluationTimes {
+		latency := evaluationTime.Seconds()
+		for i, bound := range metrics.latencyBuckets {
+			if latency <= bound {
+				metrics.latencyCounts[i]++
+			}
+		}
+		metrics.latencySum += latency
+		metrics.latencyCount++
+	}
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (metrics *Metrics[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (metrics *Metrics[T]) WriteTo(w io.Writer) (int64, error) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	var b strings.Builder
+	metrics.writeMetric(&b, "generation", "gauge", "Current generation of the population.", float64(metrics.generation))
+	metrics.writeMetric(&b, "population_size", "gauge", "Number of genomes in the population.", float64(metrics.size))
+	metrics.writeMetric(&b, "best_fitness", "gauge", "Fitness of the fittest genome in the population.", float64(metrics.bestFitness))
+	metrics.writeMetric(&b, "mean_fitness", "gauge", "Mean fitness of the population.", metrics.meanFitness)
+	if metrics.Diversity != nil {
+		metrics.writeMetric(&b, "diversity", "gauge", "Diversity of the population.", metrics.diversity)
+	}
+	metrics.writeMetric(&b, "evaluations_total", "counter", "Total number of fitness evaluations.", float64(metrics.evaluationsTotal))
+	metrics.writeMetric(&b, "restarts_total", "counter", "Total number of population restarts.", float64(metrics.restartsTotal))
+
+	name := metrics.name("evaluation_duration_seconds")
+	fmt.Fprintf(&b, "# HELP %s Duration of each call to the fitness function.\n", name)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+	for i, bound := range metrics.latencyBuckets {
+		fmt.Fprintf(&b, "%s_bucket{le=\"%s\"} %d\n", name, formatMetricValue(bound), metrics.latencyCounts[i])
+	}
+	fmt.Fprintf(&b, "%s_bucket{le=\"+Inf\"} %d\n", name, metrics.latencyCount)
+	fmt.Fprintf(&b, "%s_sum %s\n", name, formatMetricValue(metrics.latencySum))
+	fmt.Fprintf(&b, "%s_count %d\n", name, metrics.latencyCount)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// name prefixes a metric name with the namespace.
+func (metrics *Metrics[T]) name(name string) string {
+	if metrics.namespace == "" {
+		return name
+	}
+	return metrics.namespace + "_" + name
+}
+
+// writeMetric writes a single gauge or counter with its HELP and TYPE lines.
+func (metrics *Metrics[T]) writeMetric(b *strings.Builder, name, metricType, help string, value float64) {
+	name = metrics.name(name)
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, metricType)
+	fmt.Fprintf(b, "%s %s\n", name, formatMetricValue(value))
+}
+
+// formatMetricValue formats a sample value as expected by the Prometheus text format.
+func formatMetricValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,107 @@
+package genetic_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kklash/genetic"
+)
+
+func TestMetrics(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	metrics := genetic.NewMetrics[[]int]("test", 1e-9, 100)
+	metrics.Diversity = func(*genetic.Population[[]int]) float64 { return 0.25 }
+	population.Observers = append(population.Observers, metrics.Observe)
+
+	for i := 0; i < 3; i++ {
+		population.EvolveOnce(2)
+	}
+	population.Restart(genetic.RandomRestart(1))
+
+	server := httptest.NewServer(metrics)
+	defer server.Close()
+
+	response, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to scrape metrics: %s", err)
+	}
+	defer response.Body.Close()
+
+	if contentType := response.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("expected Prometheus text format content type; got %q", contentType)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %s", err)
+	}
+
+	expectedLines := []string{
+		"# TYPE test_generation gauge",
+		"test_generation 3",
+		"test_population_size 10",
+		"test_best_fitness 1",
+		"test_mean_fitness 1",
+		"test_diversity 0.25",
+		"# TYPE test_evaluations_total counter",
+		"test_evaluations_total 40",
+		// Restarts after the last generation are not observed until the next generation.
+		"test_restarts_total 0",
+		// Each generation calls the fitness function once.
+		"# TYPE test_evaluation_duration_seconds histogram",
+		`test_evaluation_duration_seconds_bucket{le="100"} 3`,
+		`test_evaluation_duration_seconds_bucket{le="+Inf"} 3`,
+		"test_evaluation_duration_seconds_count 3",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("expected metrics to contain %q; got:\n%s", line, body)
+		}
+	}
+}
+
+func TestMetricsWithoutDiversity(t *testing.T) {
+	metrics := genetic.NewMetrics[[]int]("")
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	if strings.Contains(body, "diversity") {
+		t.Errorf("expected no diversity metric without a Diversity function; got:\n%s", body)
+	}
+	if !strings.Contains(body, "\ngeneration 0\n") {
+		t.Errorf("expected unprefixed metric names without a namespace; got:\n%s", body)
+	}
+}
+
+func TestMetricsSteadyStateLatency(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(func(genome []int) int {
+		time.Sleep(time.Millisecond)
+		return twinPeaksFitness(genome)
+	}))
+	metrics := genetic.NewMetrics[[]int]("", 1e-4, 100)
+	population.Observers = append(population.Observers, metrics.Observe)
+
+	for i := 0; i < 5; i++ {
+		population.StepSteadyState()
+	}
+
+	var output strings.Builder
+	metrics.WriteTo(&output)
+
+	// Each step evaluates two children in one call, taking at least 2ms.
+	expectedLines := []string{
+		`evaluation_duration_seconds_bucket{le="0.0001"} 0`,
+		`evaluation_duration_seconds_bucket{le="100"} 5`,
+		"evaluation_duration_seconds_count 5",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output.String(), line+"\n") {
+			t.Errorf("expected metrics to contain %q; got:\n%s", line, output.String())
+		}
+	}
+}
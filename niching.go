@@ -0,0 +1,145 @@
+package genetic
+
+import (
+	"math"
+	"sort"
+)
+
+// DistanceFunc measures how dissimilar two genomes are. It should be symmetric and non-negative,
+// returning zero for genetically identical genomes.
+type DistanceFunc[T any] func(a, b T) float64
+
+// SharedFitnessFunc wraps a FitnessFunc with fitness sharing, which encourages the population to
+// spread out across several optima instead of collapsing onto a single peak. Every genome's fitness
+// is divided by its niche count: the sum of a sharing kernel, 1 - (d/radius)^alpha, over every
+// genome within the given radius of it, including itself. Genomes in crowded niches thus share
+// their fitness between them, making sparsely populated peaks relatively more attractive.
+//
+// The raw fitnesses computed by the wrapped FitnessFunc must be non-negative. Since shared fitness
+// depends on the whole population, the raw fitness of every genome is recomputed on each call,
+// and the distance function is called once for every pair of genomes. Shared fitnesses are
+// rounded down to integers, so raw fitnesses should be scaled large enough to tolerate this.
+func SharedFitnessFunc[T any](fitness FitnessFunc[T], distance DistanceFunc[T], radius, alpha float64) FitnessFunc[T] {
+	if radius <= 0 {
+		panic("fitness sharing radius must be positive")
+	} else if alpha <= 0 {
+		panic("fitness sharing alpha must be positive")
+	}
+
+	return func(genomes []T, fitnesses []int, _ []bool) {
+		rawFitnesses := unknownFitnesses(len(genomes))
+		fitness(genomes, rawFitnesses, make([]bool, len(genomes)))
+
+		nicheCounts := make([]float64, len(genomes))
+		for i := range genomes {
+			nicheCounts[i] += 1 // sharing with itself
+			for j := i + 1; j < len(genomes); j++ {
+				d := distance(genomes[i], genomes[j])
+				if d < radius {
+					share := 1 - math.Pow(d/radius, alpha)
+					nicheCounts[i] += share
+					nicheCounts[j] += share
+				}
+			}
+		}
+
+		for i, raw := range rawFitnesses {
+			fitnesses[i] = int(float64(raw) / nicheCounts[i])
+		}
+	}
+}
+
+// ClearingFitnessFunc wraps a FitnessFunc with the clearing procedure, an alternative to fitness
+// sharing. Within each niche of the given radius, only the fittest capacity genomes keep their
+// fitness; the fitness of every other genome in that niche is cleared to zero. Niches are formed
+// around the fittest remaining genome, in descending order of fitness.
+//
+// The raw fitnesses computed by the wrapped FitnessFunc should be positive, so that cleared genomes
+// are always less fit than niche winners. Since clearing depends on the whole population, the raw
+// fitness of every genome is recomputed on each call.
+func ClearingFitnessFunc[T any](fitness FitnessFunc[T], distance DistanceFunc[T], radius float64, capacity int) FitnessFunc[T] {
+	if radius <= 0 {
+		panic("clearing radius must be positive")
+	} else if capacity < 1 {
+		panic("clearing capacity must be at least 1")
+	}
+
+	return func(genomes []T, fitnesses []int, _ []bool) {
+		rawFitnesses := unknownFitnesses(len(genomes))
+		fitness(genomes, rawFitnesses, make([]bool, len(genomes)))
+
+		order := make([]int, len(genomes))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return rawFitnesses[order[a]] > rawFitnesses[order[b]]
+		})
+
+		cleared := make([]bool, len(genomes))
+		for a, i := range order {
+			fitnesses[i] = rawFitnesses[i]
+			if cleared[i] {
+				fitnesses[i] = 0
+				continue
+			}
+
+			// Genome i is the fittest remaining genome, and so is the first winner of a new niche.
+			winners := 1
+			for _, j := range order[a+1:] {
+				if cleared[j] || distance(genomes[i], genomes[j]) >= radius {
+					continue
+				}
+				if winners < capacity {
+					winners++
+				} else {
+					cleared[j] = true
+				}
+			}
+		}
+	}
+}
+
+// Niches returns representatives of the distinct niches in a set of genomes, along with their
+// fitnesses. Genomes are considered in descending order of fitness, and a genome becomes a niche
+// representative if it is not within radius of any representative chosen before it.
+// Representatives are returned in descending order of fitness.
+func Niches[T any](genomes []T, fitnesses []int, distance DistanceFunc[T], radius float64) ([]T, []int) {
+	order := make([]int, len(genomes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return fitnesses[order[a]] > fitnesses[order[b]]
+	})
+
+	var representatives []T
+	var representativeFitnesses []int
+
+	for _, i := range order {
+		isNewNiche := true
+		for _, representative := range representatives {
+			if distance(genomes[i], representative) < radius {
+				isNewNiche = false
+				break
+			}
+		}
+
+		if isNewNiche {
+			representatives = append(representatives, genomes[i])
+			representativeFitnesses = append(representativeFitnesses, fitnesses[i])
+		}
+	}
+
+	return representatives, representativeFitnesses
+}
+
+// Niches returns representatives of the distinct niches in the population, along with their
+// fitnesses, in descending order of fitness. See the Niches function for details.
+//
+// When the population is evolved with SharedFitnessFunc or ClearingFitnessFunc, the fitnesses
+// returned are the derated fitnesses, not the raw fitnesses.
+func (population *Population[T]) Niches(distance DistanceFunc[T], radius float64) ([]T, []int) {
+	genomes, fitnesses := population.members()
+	return Niches(genomes, fitnesses, distance, radius)
+}
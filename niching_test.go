@@ -0,0 +1,104 @@
+package genetic_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+// twinPeaksFitness has two equally high optima, at 20 and 80.
+func twinPeaksFitness(genome []int) int {
+	x := genome[0]
+	d1, d2 := x-20, x-80
+	if d1 < 0 {
+		d1 = -d1
+	}
+	if d2 < 0 {
+		d2 = -d2
+	}
+	if d2 < d1 {
+		d1 = d2
+	}
+	if d1 >= 100 {
+		return 1 // sharing and clearing require positive fitness
+	}
+	return 1000 - d1*10
+}
+
+func lineDistance(a, b []int) float64 {
+	d := a[0] - b[0]
+	if d < 0 {
+		d = -d
+	}
+	return float64(d)
+}
+
+func newTwinPeaksPopulation(fitness genetic.FitnessFunc[[]int]) *genetic.Population[[]int] {
+	return genetic.NewPopulation(
+		60,
+		func() []int { return []int{rand.Intn(100)} },
+		func(male, female []int) ([]int, []int) {
+			return []int{male[0]}, []int{female[0]}
+		},
+		fitness,
+		genetic.TournamentSelection[[]int](2),
+		func(genome []int) {
+			genome[0] += rand.Intn(5) - 2
+		},
+	)
+}
+
+func testSurvivingNiches(t *testing.T, fitness genetic.FitnessFunc[[]int]) {
+	population := newTwinPeaksPopulation(fitness)
+	for i := 0; i < 100; i++ {
+		population.EvolveOnce(2)
+	}
+
+	representatives, _ := population.Niches(lineDistance, 5)
+
+	var foundLow, foundHigh bool
+	for _, genome := range representatives {
+		if twinPeaksFitness(genome) >= 900 {
+			foundLow = foundLow || genome[0] < 50
+			foundHigh = foundHigh || genome[0] >= 50
+		}
+	}
+	if !foundLow || !foundHigh {
+		t.Errorf("expected both optima to survive as niches; got %v", representatives)
+	}
+}
+
+func TestSharedFitnessFunc(t *testing.T) {
+	testSurvivingNiches(t, genetic.SharedFitnessFunc(
+		genetic.StaticFitnessFunc(twinPeaksFitness),
+		lineDistance,
+		5,
+		1,
+	))
+}
+
+func TestClearingFitnessFunc(t *testing.T) {
+	testSurvivingNiches(t, genetic.ClearingFitnessFunc(
+		genetic.StaticFitnessFunc(twinPeaksFitness),
+		lineDistance,
+		10,
+		3,
+	))
+}
+
+func TestNiches(t *testing.T) {
+	genomes := [][]int{{10}, {12}, {50}, {51}, {90}}
+	fitnesses := []int{5, 9, 7, 3, 1}
+
+	representatives, representativeFitnesses := genetic.Niches(genomes, fitnesses, lineDistance, 5)
+	if len(representatives) != 3 {
+		t.Fatalf("expected 3 niches; got %v", representatives)
+	}
+	if representatives[0][0] != 12 || representatives[1][0] != 50 || representatives[2][0] != 90 {
+		t.Errorf("unexpected niche representatives: %v", representatives)
+	}
+	if representativeFitnesses[0] != 9 || representativeFitnesses[1] != 7 || representativeFitnesses[2] != 1 {
+		t.Errorf("unexpected niche representative fitnesses: %v", representativeFitnesses)
+	}
+}
@@ -0,0 +1,317 @@
+package genetic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MultiObjectiveFitnessFunc calculates a vector of objective scores for every genome in a
+// population, storing the results in the given objectives slice. Every objective is maximized;
+// negate any objective which should be minimized. Every genome must be scored on the same
+// objectives, in the same order.
+//
+// Some entries in objectives may be prepopulated (non-nil) - these are cached objective scores for
+// genomes surviving from the previous generation. A MultiObjectiveFitnessFunc may recalculate or
+// skip them as needed.
+type MultiObjectiveFitnessFunc[T any] func(genomes []T, objectives [][]float64)
+
+// StaticMultiObjectiveFitnessFunc is a utility which maps a static non-competitive function
+// scoring a single genome on every objective into a MultiObjectiveFitnessFunc[T].
+func StaticMultiObjectiveFitnessFunc[T any](fitness func(T) []float64) MultiObjectiveFitnessFunc[T] {
+	return func(genomes []T, objectives [][]float64) {
+		for i, genome := range genomes {
+			if objectives[i] == nil {
+				// Only calculate objectives for genomes whose objectives are unknown.
+				objectives[i] = fitness(genome)
+			}
+		}
+	}
+}
+
+// Dominates returns true if the objective vector a Pareto-dominates b: a is at least as
+// good as b on every objective, and strictly better on at least one.
+func Dominates(a, b []float64) bool {
+	if len(a) != len(b) {
+		panic("cannot compare objective vectors of mismatching length")
+	}
+
+	strictlyBetter := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		} else if a[i] > b[i] {
+			strictlyBetter = true
+		}
+	}
+	return strictlyBetter
+}
+
+// NonDominatedSort partitions a set of objective vectors into Pareto fronts, returning the
+// indexes of the vectors in each front. The first front contains every vector which is not
+// dominated by any other. Each subsequent front contains those vectors which are dominated
+// only by members of earlier fronts.
+func NonDominatedSort(objectives [][]float64) (fronts [][]int) {
+	dominatedBy := make([]int, len(objectives))
+	dominates := make([][]int, len(objectives))
+
+	var front []int
+	for i := range objectives {
+		for j := i + 1; j < len(objectives); j++ {
+			if Dominates(objectives[i], objectives[j]) {
+				dominates[i] = append(dominates[i], j)
+				dominatedBy[j]++
+			} else if Dominates(objectives[j], objectives[i]) {
+				dominates[j] = append(dominates[j], i)
+				dominatedBy[i]++
+			}
+		}
+		if dominatedBy[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+
+		var nextFront []int
+		for _, i := range front {
+			for _, j := range dominates[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					nextFront = append(nextFront, j)
+				}
+			}
+		}
+		front = nextFront
+	}
+
+	return fronts
+}
+
+// CrowdingDistances computes the NSGA-II crowding distance of each member of a front, given
+// as indexes into objectives. The crowding distance of a member is the sum over all objectives
+// of the normalized distance between its two nearest neighbours in that objective. Boundary
+// members of the front have an infinite crowding distance. Larger distances indicate members
+// in less crowded regions of the front.
+func CrowdingDistances(objectives [][]float64, front []int) []float64 {
+	distances := make([]float64, len(front))
+	if len(front) == 0 {
+		return distances
+	}
+
+	order := make([]int, len(front))
+	objectiveCount := len(objectives[front[0]])
+
+	for m := 0; m < objectiveCount; m++ {
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool {
+			return objectives[front[order[a]]][m] < objectives[front[order[b]]][m]
+		})
+
+		lowest := objectives[front[order[0]]][m]
+		highest := objectives[front[order[len(order)-1]]][m]
+		distances[order[0]] = math.Inf(1)
+		distances[order[len(order)-1]] = math.Inf(1)
+
+		if highest == lowest {
+			continue
+		}
+
+		for k := 1; k < len(order)-1; k++ {
+			previous := objectives[front[order[k-1]]][m]
+			next := objectives[front[order[k+1]]][m]
+			distances[order[k]] += (next - previous) / (highest - lowest)
+		}
+	}
+
+	return distances
+}
+
+// MultiObjectivePopulation is a population of genomes of type T which are evolved against
+// several competing objectives at once using the NSGA-II algorithm. Instead of a single
+// best genome, it maintains a Pareto front of genomes which represent the best available
+// trade-offs between objectives.
+type MultiObjectivePopulation[T any] struct {
+	genomes    []T
+	objectives [][]float64
+	ranks      []int
+	crowding   []float64
+
+	// Crossover is used to recombine two genomes of type T.
+	Crossover CrossoverFunc[T]
+
+	// Fitness computes the objective scores of a population of genomes of type T.
+	Fitness MultiObjectiveFitnessFunc[T]
+
+	// Mutation randomly mutates a genome.
+	Mutation MutationFunc[T]
+
+	// Archive is an optional external archive, which is updated with the Pareto front
+	// at the end of each generation.
+	Archive *SPEA2Archive[T]
+}
+
+// NewMultiObjectivePopulation initializes a MultiObjectivePopulation of genomes of the given size.
+// The generate function is used to create a genome population of the given size.
+//
+// Unlike Population, a MultiObjectivePopulation has no configurable SelectionFunc. Mates are
+// chosen by binary tournaments comparing Pareto rank, then crowding distance.
+func NewMultiObjectivePopulation[T any](
+	size int,
+	generate GenesisFunc[T],
+	crossover CrossoverFunc[T],
+	fitness MultiObjectiveFitnessFunc[T],
+	mutation MutationFunc[T],
+) *MultiObjectivePopulation[T] {
+
+	if size < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; got %d", PopulationSizeMinimum, size))
+	} else if generate == nil {
+		panic("expected to receive GenesisFunc")
+	} else if crossover == nil {
+		panic("expected to receive CrossoverFunc")
+	} else if fitness == nil {
+		panic("expected to receive MultiObjectiveFitnessFunc")
+	}
+
+	population := &MultiObjectivePopulation[T]{
+		genomes:    make([]T, size),
+		objectives: make([][]float64, size),
+		Crossover:  crossover,
+		Fitness:    fitness,
+		Mutation:   mutation,
+	}
+
+	for i := 0; i < size; i++ {
+		population.genomes[i] = generate()
+	}
+
+	fitness(population.genomes, population.objectives)
+	population.survive(population.genomes, population.objectives, size)
+
+	return population
+}
+
+// survive performs NSGA-II environmental selection, choosing size survivors from the given genomes
+// by filling whole Pareto fronts in order, and truncating the last front which does not fit by
+// preferring members with larger crowding distances. The population is left ordered by rank,
+// then by descending crowding distance.
+func (population *MultiObjectivePopulation[T]) survive(genomes []T, objectives [][]float64, size int) {
+	nextGenomes := make([]T, 0, size)
+	nextObjectives := make([][]float64, 0, size)
+	nextRanks := make([]int, 0, size)
+	nextCrowding := make([]float64, 0, size)
+
+	for rank, front := range NonDominatedSort(objectives) {
+		if len(nextGenomes) >= size {
+			break
+		}
+
+		distances := CrowdingDistances(objectives, front)
+		order := make([]int, len(front))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return distances[order[a]] > distances[order[b]]
+		})
+
+		for _, k := range order {
+			if len(nextGenomes) >= size {
+				break
+			}
+			nextGenomes = append(nextGenomes, genomes[front[k]])
+			nextObjectives = append(nextObjectives, objectives[front[k]])
+			nextRanks = append(nextRanks, rank)
+			nextCrowding = append(nextCrowding, distances[k])
+		}
+	}
+
+	population.genomes = nextGenomes
+	population.objectives = nextObjectives
+	population.ranks = nextRanks
+	population.crowding = nextCrowding
+}
+
+// crowdedTournamentWinner runs a binary tournament between two random members of the population.
+// The member with the lower Pareto rank wins; ties are broken by the larger crowding distance.
+func (population *MultiObjectivePopulation[T]) crowdedTournamentWinner() int {
+	contestants := randRangeIntsUnique(len(population.genomes), 2)
+	a, b := contestants[0], contestants[1]
+
+	if population.ranks[a] != population.ranks[b] {
+		if population.ranks[a] < population.ranks[b] {
+			return a
+		}
+		return b
+	}
+	if population.crowding[a] >= population.crowding[b] {
+		return a
+	}
+	return b
+}
+
+// EvolveOnce evolves the population by one generation. A full population's worth of children
+// are bred from parents chosen by crowded binary tournaments, and then the best of the combined
+// parents and children survive, as ranked by Pareto front and crowding distance. This makes
+// NSGA-II inherently elitist: no member of the Pareto front is lost unless it is replaced by a
+// dominating genome, or truncated from an overcrowded front.
+func (population *MultiObjectivePopulation[T]) EvolveOnce() {
+	size := len(population.genomes)
+
+	childGenomes := make([]T, 0, size+1)
+	for len(childGenomes) < size {
+		mate1Index := population.crowdedTournamentWinner()
+		mate2Index := population.crowdedTournamentWinner()
+		if mate1Index == mate2Index {
+			continue
+		}
+
+		offspring1, offspring2 := population.Crossover(population.genomes[mate1Index], population.genomes[mate2Index])
+		if population.Mutation != nil {
+			population.Mutation(offspring1)
+			population.Mutation(offspring2)
+		}
+		childGenomes = append(childGenomes, offspring1, offspring2)
+	}
+
+	nextGenomes := make([]T, size+len(childGenomes))
+	copy(nextGenomes, population.genomes)
+	copy(nextGenomes[size:], childGenomes)
+
+	nextObjectives := make([][]float64, len(nextGenomes))
+	copy(nextObjectives, population.objectives)
+
+	population.Fitness(nextGenomes, nextObjectives)
+	population.survive(nextGenomes, nextObjectives, size)
+
+	if population.Archive != nil {
+		population.Archive.Update(population.ParetoFront())
+	}
+}
+
+// Evolve evolves the population for the given number of generations.
+func (population *MultiObjectivePopulation[T]) Evolve(generations int) {
+	for i := 0; i < generations; i++ {
+		population.EvolveOnce()
+	}
+}
+
+// ParetoFront returns the genomes in the population which are not dominated by any other
+// genome, along with their objective scores. This is the multi-objective analogue
+// of Population.Best.
+func (population *MultiObjectivePopulation[T]) ParetoFront() ([]T, [][]float64) {
+	n := 0
+	for n < len(population.ranks) && population.ranks[n] == 0 {
+		n++
+	}
+
+	genomes := make([]T, n)
+	objectives := make([][]float64, n)
+	copy(genomes, population.genomes[:n])
+	copy(objectives, population.objectives[:n])
+	return genomes, objectives
+}
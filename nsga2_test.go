@@ -0,0 +1,75 @@
+package genetic_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestNonDominatedSort(t *testing.T) {
+	objectives := [][]float64{
+		{1, 2}, // front 2
+		{3, 1}, // front 1
+		{1, 3}, // front 1
+		{2, 2}, // front 1
+		{0, 0}, // front 3
+		{2, 1}, // front 2
+	}
+
+	fronts := genetic.NonDominatedSort(objectives)
+	expected := "[[1 2 3] [0 5] [4]]"
+	if fmt.Sprint(fronts) != expected {
+		t.Errorf("unexpected fronts\nWanted %s\nGot    %v", expected, fronts)
+	}
+}
+
+func TestCrowdingDistances(t *testing.T) {
+	objectives := [][]float64{
+		{0, 4},
+		{1, 3},
+		{3, 1},
+		{4, 0},
+	}
+
+	distances := genetic.CrowdingDistances(objectives, []int{0, 1, 2, 3})
+	if !math.IsInf(distances[0], 1) || !math.IsInf(distances[3], 1) {
+		t.Errorf("expected boundary members to have infinite crowding distance; got %v", distances)
+	}
+	if distances[1] != distances[2] || distances[1] != 1.5 {
+		t.Errorf("expected interior crowding distances of 1.5; got %v", distances)
+	}
+}
+
+func TestMultiObjectivePopulation(t *testing.T) {
+	// Schaffer's function: minimize x^2 and (x-2)^2. The Pareto-optimal set is 0 <= x <= 2.
+	population := genetic.NewMultiObjectivePopulation(
+		40,
+		func() []float64 { return []float64{rand.Float64()*20 - 10} },
+		func(male, female []float64) ([]float64, []float64) {
+			w := rand.Float64()
+			return []float64{w*male[0] + (1-w)*female[0]}, []float64{(1-w)*male[0] + w*female[0]}
+		},
+		genetic.StaticMultiObjectiveFitnessFunc(func(genome []float64) []float64 {
+			x := genome[0]
+			return []float64{-x * x, -(x - 2) * (x - 2)}
+		}),
+		func(genome []float64) {
+			genome[0] += rand.NormFloat64() * 0.1
+		},
+	)
+
+	population.Evolve(50)
+
+	front, objectives := population.ParetoFront()
+	if len(front) < 10 {
+		t.Fatalf("expected a well-populated Pareto front; got %d members", len(front))
+	}
+	for i, genome := range front {
+		if genome[0] < -0.05 || genome[0] > 2.05 {
+			t.Errorf("expected Pareto front member within [0, 2]; got %f with objectives %v", genome[0], objectives[i])
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package genetic
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// GenerationStats summarizes a single generation, or steady-state step, of a Population.
+type GenerationStats struct {
+	// Generation is the number of the generation which has just completed.
+	Generation int
+
+	// Size is the size of the population at the end of the generation.
+	Size int
+
+	// BestFitness is the fitness of the fittest genome at the end of the generation.
+	BestFitness int
+
+	// MeanFitness is the mean fitness of the population at the end of the generation.
+	MeanFitness float64
+
+	// Evaluations is the number of fitnesses computed during the generation.
+	Evaluations int
+
+	// TotalEvaluations is the number of fitnesses computed since the population was created,
+	// including those of the initial population.
+	TotalEvaluations int
+
+	// EvaluationTime is the time spent in the population's FitnessFunc during the generation.
+	// During asynchronous evolution, it sums the time spent by every worker.
+	EvaluationTime time.Duration
+
+	// EvaluationTimes holds the duration of each call to the population's FitnessFunc during the
+	// generation which evaluated any genomes. A call evaluates the whole generation of children
+	// during EvolveOnce, and the two children of a single step during steady-state or asynchronous
+	// evolution.
+	EvaluationTimes []time.Duration
+
+	// Duration is the wall-clock time elapsed since the previous generation completed.
+	Duration time.Duration
+
+	// Time is when the generation completed.
+	Time time.Time
+}
+
+// ObserverFunc is called after every generation, or steady-state step, of a population with
+// statistics about it. It is called synchronously from within the evolution loop, so it should
+// return quickly, and should NOT evolve or otherwise modify the population.
+type ObserverFunc[T any] func(population *Population[T], stats GenerationStats)
+
+// Evaluations returns the number of fitnesses computed since the population was created.
+func (population *Population[T]) Evaluations() int {
+	return int(atomic.LoadInt64(&population.evaluations))
+}
+
+// resetObservation starts measuring the next generation.
+func (population *Population[T]) resetObservation() {
+	atomic.StoreInt64(&population.pendingEvaluations, 0)
+	atomic.StoreInt64(&population.pendingEvaluationTime, 0)
+	population.takeEvaluationTimes()
+	population.lastObserved = time.Now()
+}
+
+// notifyObservers calls the population's observers with statistics about the
+// generation which has just completed.
+func (population *Population[T]) notifyObservers() {
+	now := time.Now()
+	stats := GenerationStats{
+		Generation:       population.generation,
+		Size:             len(population.genomes),
+		BestFitness:      population.fitnesses[0],
+		MeanFitness:      meanFitness(population.fitnesses),
+		Evaluations:      int(atomic.SwapInt64(&population.pendingEvaluations, 0)),
+		TotalEvaluations: int(atomic.LoadInt64(&population.evaluations)),
+		EvaluationTime:   time.Duration(atomic.SwapInt64(&population.pendingEvaluationTime, 0)),
+		EvaluationTimes:  population.takeEvaluationTimes(),
+		Duration:         now.Sub(population.lastObserved),
+		Time:             now,
+	}
+	population.lastObserved = now
+
+	population.logGeneration(stats)
+	for _, observe := range population.Observers {
+		observe(population, stats)
+	}
+}
+
+// takeEvaluationTimes returns the durations of the fitness evaluations since it was last called.
+func (population *Population[T]) takeEvaluationTimes() []time.Duration {
+	population.timesMutex.Lock()
+	defer population.timesMutex.Unlock()
+
+	times := population.pendingEvaluationTimes
+	population.pendingEvaluationTimes = nil
+	return times
+}
@@ -0,0 +1,53 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestPopulationObservers(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	if evaluations := population.Evaluations(); evaluations != 10 {
+		t.Fatalf("expected initial population to take 10 evaluations; got %d", evaluations)
+	}
+
+	var observed []genetic.GenerationStats
+	population.Observers = append(population.Observers, func(p *genetic.Population[[]int], stats genetic.GenerationStats) {
+		if p != population {
+			t.Errorf("expected observer to receive the observed population")
+		}
+		observed = append(observed, stats)
+	})
+
+	for i := 0; i < 3; i++ {
+		population.EvolveOnce(2)
+	}
+	population.StepSteadyState()
+
+	if len(observed) != 4 {
+		t.Fatalf("expected 4 observations; got %d", len(observed))
+	}
+
+	for i, stats := range observed[:3] {
+		if stats.Generation != i+1 {
+			t.Errorf("expected generation %d; got %d", i+1, stats.Generation)
+		}
+		if stats.Evaluations != 10 {
+			t.Errorf("expected 10 evaluations in generation %d; got %d", stats.Generation, stats.Evaluations)
+		}
+		if stats.TotalEvaluations != 10*(i+2) {
+			t.Errorf("expected %d total evaluations; got %d", 10*(i+2), stats.TotalEvaluations)
+		}
+		if stats.Size != 10 || stats.BestFitness != 1 || stats.MeanFitness != 1 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	}
+
+	if steady := observed[3]; steady.Evaluations != 2 || steady.TotalEvaluations != 42 {
+		t.Errorf("expected steady-state step to take 2 evaluations; got %+v", steady)
+	}
+	if evaluations := population.Evaluations(); evaluations != 42 {
+		t.Errorf("expected 42 evaluations in total; got %d", evaluations)
+	}
+}
@@ -0,0 +1,52 @@
+package genetic_test
+
+import (
+	"github.com/kklash/genetic"
+)
+
+// constantFitness gives every genome a fitness of 1.
+var constantFitness = genetic.StaticFitnessFunc(func([]int) int { return 1 })
+
+// geneFitness uses the first gene of a genome as its fitness.
+var geneFitness = genetic.StaticFitnessFunc(func(genome []int) int { return genome[0] })
+
+// newTestPopulation creates a population of the given genomes, evaluated by the given fitness
+// function. Children are copies of their parents, and are never mutated. Genomes created later,
+// such as by restarts or resizing, hold a single gene counting up from the number of genomes given.
+func newTestPopulation(fitness genetic.FitnessFunc[[]int], genomes ...[]int) *genetic.Population[[]int] {
+	next := 0
+	return genetic.NewPopulation(
+		len(genomes),
+		func() []int {
+			next++
+			if next <= len(genomes) {
+				return genomes[next-1]
+			}
+			return []int{next}
+		},
+		func(male, female []int) ([]int, []int) {
+			return append([]int(nil), male...), append([]int(nil), female...)
+		},
+		fitness,
+		genetic.TournamentSelection[[]int](2),
+		nil,
+	)
+}
+
+// genomesOf returns a single-gene genome for each of the given values.
+func genomesOf(values ...int) [][]int {
+	genomes := make([][]int, len(values))
+	for i, value := range values {
+		genomes[i] = []int{value}
+	}
+	return genomes
+}
+
+// countingGenomes returns n single-gene genomes, counting up from 1.
+func countingGenomes(n int) [][]int {
+	genomes := make([][]int, n)
+	for i := range genomes {
+		genomes[i] = []int{i + 1}
+	}
+	return genomes
+}
@@ -0,0 +1,120 @@
+package genetic
+
+import (
+	"fmt"
+)
+
+// pyramidRate is the number of generations a population in a PopulationPyramid evolves for
+// each generation of the next larger population.
+const pyramidRate = 4
+
+// PopulationPyramid implements the parameterless genetic algorithm of Harik and Lobo, which removes
+// the need to choose a population size. It races a pyramid of populations against one another, each
+// twice the size of the last. Smaller populations evolve more often than larger ones: each population
+// evolves four generations for every generation of the next larger population. A new population,
+// larger than all others, is added whenever the largest population is due to evolve.
+//
+// Small populations converge quickly, but often prematurely. Whenever a larger population overtakes a
+// smaller one in mean fitness, the smaller population and any populations smaller still are discarded,
+// as they are unlikely to produce better solutions than the larger one.
+type PopulationPyramid[T any] struct {
+	newPopulation func(size int) *Population[T]
+	nextSize      int
+	populations   []*Population[T]
+	counter       int
+}
+
+// NewPopulationPyramid initializes a PopulationPyramid whose smallest population has the given
+// initialSize. The newPopulation function is called to create each population of the pyramid with
+// the given size, for example by calling NewPopulation with the desired operators.
+func NewPopulationPyramid[T any](initialSize int, newPopulation func(size int) *Population[T]) *PopulationPyramid[T] {
+	if initialSize < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; got %d", PopulationSizeMinimum, initialSize))
+	} else if newPopulation == nil {
+		panic("expected to receive population constructor")
+	}
+
+	pyramid := &PopulationPyramid[T]{
+		newPopulation: newPopulation,
+		nextSize:      initialSize,
+	}
+	pyramid.grow()
+	return pyramid
+}
+
+// grow adds a new population to the pyramid, twice the size of the previous one.
+func (pyramid *PopulationPyramid[T]) grow() {
+	pyramid.populations = append(pyramid.populations, pyramid.newPopulation(pyramid.nextSize))
+	pyramid.nextSize *= 2
+}
+
+// EvolveOnce evolves one population of the pyramid by a single generation, with the given elitism.
+// Which population evolves is decided by a counter in base four: the smallest population evolves on
+// every step not divisible by four, the next population on every step divisible by four but not by
+// sixteen, and so on.
+func (pyramid *PopulationPyramid[T]) EvolveOnce(elitism int) {
+	pyramid.counter++
+
+	level := 0
+	for n := pyramid.counter; n%pyramidRate == 0; n /= pyramidRate {
+		level++
+	}
+
+	if level >= len(pyramid.populations) {
+		pyramid.grow()
+		level = len(pyramid.populations) - 1
+	}
+
+	pyramid.populations[level].EvolveOnce(elitism)
+	pyramid.prune()
+}
+
+// prune discards every population which has been overtaken in mean fitness by a larger population.
+func (pyramid *PopulationPyramid[T]) prune() {
+	overtaken := -1
+	for i := 0; i < len(pyramid.populations)-1; i++ {
+		mean := meanFitness(pyramid.populations[i].fitnesses)
+		for _, larger := range pyramid.populations[i+1:] {
+			if meanFitness(larger.fitnesses) > mean {
+				overtaken = i
+				break
+			}
+		}
+	}
+
+	if overtaken >= 0 {
+		pyramid.populations = pyramid.populations[overtaken+1:]
+		pyramid.counter = 0
+	}
+}
+
+// Evolve evolves the pyramid until either a genome is produced which meets the given
+// fitnessThreshold, or maxSteps generations have been evolved across all populations.
+func (pyramid *PopulationPyramid[T]) Evolve(fitnessThreshold, maxSteps, elitism int) {
+	for i := 0; i < maxSteps; i++ {
+		_, bestFitness := pyramid.Best()
+		if bestFitness >= fitnessThreshold {
+			break
+		}
+
+		pyramid.EvolveOnce(elitism)
+	}
+}
+
+// Best returns the fittest genome and fitness across every population in the pyramid.
+func (pyramid *PopulationPyramid[T]) Best() (T, int) {
+	best, bestFitness := pyramid.populations[0].Best()
+	for _, population := range pyramid.populations[1:] {
+		if genome, fitness := population.Best(); fitness > bestFitness {
+			best, bestFitness = genome, fitness
+		}
+	}
+	return best, bestFitness
+}
+
+// Populations returns the populations currently in the pyramid, from smallest to largest.
+func (pyramid *PopulationPyramid[T]) Populations() []*Population[T] {
+	populations := make([]*Population[T], len(pyramid.populations))
+	copy(populations, pyramid.populations)
+	return populations
+}
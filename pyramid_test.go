@@ -0,0 +1,35 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestPopulationPyramid(t *testing.T) {
+	pyramid := genetic.NewPopulationPyramid(4, func(size int) *genetic.Population[[]int] {
+		population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+		population.Resize(size)
+		return population
+	})
+
+	if populations := pyramid.Populations(); len(populations) != 1 || len(populations[0].Individuals()) != 4 {
+		t.Fatalf("expected pyramid to start with a single population of size 4")
+	}
+
+	for i := 0; i < 200; i++ {
+		pyramid.EvolveOnce(1)
+
+		populations := pyramid.Populations()
+		for j := 1; j < len(populations); j++ {
+			if 2*len(populations[j-1].Individuals()) != len(populations[j].Individuals()) {
+				t.Fatalf("expected each population in pyramid to be twice the size of the last")
+			}
+		}
+	}
+
+	pyramid.Evolve(1000, 2000, 1)
+	if _, bestFitness := pyramid.Best(); bestFitness != 1000 {
+		t.Errorf("expected pyramid to find a peak; got best fitness %d", bestFitness)
+	}
+}
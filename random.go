@@ -38,3 +38,10 @@ func randFloat() float64 {
 	randMutex.Unlock()
 	return n
 }
+
+func randPerm(n int) []int {
+	randMutex.Lock()
+	perm := random.Perm(n)
+	randMutex.Unlock()
+	return perm
+}
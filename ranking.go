@@ -0,0 +1,102 @@
+package genetic
+
+import (
+	"fmt"
+	"math"
+)
+
+// Len returns the number of genomes in the population.
+func (population *Population[T]) Len() int {
+	genomes, _ := population.members()
+	return len(genomes)
+}
+
+// GenomeAt returns the genome ranked i in the population, where rank 0 is the fittest genome.
+func (population *Population[T]) GenomeAt(i int) T {
+	genomes, _ := population.members()
+	return genomes[i]
+}
+
+// FitnessAt returns the fitness of the genome ranked i in the population, where rank 0 is
+// the fittest genome.
+func (population *Population[T]) FitnessAt(i int) int {
+	_, fitnesses := population.members()
+	return fitnesses[i]
+}
+
+// TopN returns the n fittest genomes in the population and their fitnesses, in descending order
+// of fitness, for example to build an ensemble from the best solutions found. If n exceeds the
+// size of the population, every genome is returned.
+func (population *Population[T]) TopN(n int) ([]T, []int) {
+	genomes, fitnesses := population.members()
+	if n > len(genomes) {
+		n = len(genomes)
+	}
+	n = max(n, 0)
+
+	topGenomes := make([]T, n)
+	topFitnesses := make([]int, n)
+	copy(topGenomes, genomes)
+	copy(topFitnesses, fitnesses)
+	return topGenomes, topFitnesses
+}
+
+// All returns an iterator over every genome in the population and its fitness, in descending
+// order of fitness. The iterator calls yield for each genome in turn, stopping early if yield
+// returns false. It sees the population as it was when All was called, even if the population
+// evolves during iteration. With Go 1.23 or later, it can be used in a range loop:
+//
+//	for genome, fitness := range population.All() {
+//		fmt.Println(genome, fitness)
+//	}
+func (population *Population[T]) All() func(yield func(T, int) bool) {
+	genomes, fitnesses := population.members()
+	return func(yield func(T, int) bool) {
+		for i, genome := range genomes {
+			if !yield(genome, fitnesses[i]) {
+				return
+			}
+		}
+	}
+}
+
+// FitnessQuantile returns the q-quantile of the population's fitnesses, interpolating linearly
+// between the nearest ranks, for q between 0 and 1. FitnessQuantile(0) is the lowest fitness,
+// FitnessQuantile(0.5) is the median, and FitnessQuantile(1) is the highest.
+func (population *Population[T]) FitnessQuantile(q float64) float64 {
+	_, fitnesses := population.members()
+	return fitnessQuantile(fitnesses, q)
+}
+
+// FitnessQuantiles returns the given quantiles of the population's fitnesses, as computed by
+// FitnessQuantile, all taken from the same generation.
+func (population *Population[T]) FitnessQuantiles(qs ...float64) []float64 {
+	_, fitnesses := population.members()
+
+	quantiles := make([]float64, len(qs))
+	for i, q := range qs {
+		quantiles[i] = fitnessQuantile(fitnesses, q)
+	}
+	return quantiles
+}
+
+// MedianFitness returns the median of the population's fitnesses.
+func (population *Population[T]) MedianFitness() float64 {
+	return population.FitnessQuantile(0.5)
+}
+
+// fitnessQuantile computes the q-quantile of fitnesses, which are in descending order.
+func fitnessQuantile(fitnesses []int, q float64) float64 {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		panic(fmt.Sprintf("invalid quantile %v, must be between 0 and 1", q))
+	}
+
+	// Rank from the least fit genome, which is last.
+	position := q * float64(len(fitnesses)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	last := len(fitnesses) - 1
+
+	low, high := float64(fitnesses[last-lower]), float64(fitnesses[last-upper])
+	return low + (high-low)*(position-float64(lower))
+}
@@ -0,0 +1,76 @@
+package genetic_test
+
+import (
+	"testing"
+)
+
+func TestPopulationRankedAccessors(t *testing.T) {
+	population := newTestPopulation(geneFitness, genomesOf(30, 10, 50, 20, 40)...)
+
+	if n := population.Len(); n != 5 {
+		t.Errorf("expected Len 5; got %d", n)
+	}
+	if fitness := population.FitnessAt(1); fitness != 40 {
+		t.Errorf("expected runner-up fitness 40; got %d", fitness)
+	}
+	if genome := population.GenomeAt(4); genome[0] != 10 {
+		t.Errorf("expected least fit genome [10]; got %v", genome)
+	}
+
+	genomes, fitnesses := population.TopN(3)
+	if len(genomes) != 3 || genomes[0][0] != 50 || genomes[2][0] != 30 || fitnesses[1] != 40 {
+		t.Errorf("unexpected top 3: %v %v", genomes, fitnesses)
+	}
+	fitnesses[0] = 1000
+	if _, bestFitness := population.Best(); bestFitness != 50 {
+		t.Errorf("expected TopN to return copies")
+	}
+	if genomes, _ := population.TopN(10); len(genomes) != 5 {
+		t.Errorf("expected TopN to be capped at the population size; got %d", len(genomes))
+	}
+
+	var iterated []int
+	population.All()(func(genome []int, fitness int) bool {
+		if genome[0] != fitness {
+			t.Errorf("expected genome %v to have fitness %d", genome, genome[0])
+		}
+		iterated = append(iterated, fitness)
+		return len(iterated) < 4
+	})
+	if len(iterated) != 4 || iterated[0] != 50 || iterated[3] != 20 {
+		t.Errorf("expected iteration in descending order of fitness; got %v", iterated)
+	}
+}
+
+func TestPopulationFitnessQuantiles(t *testing.T) {
+	population := newTestPopulation(geneFitness, genomesOf(30, 10, 50, 20, 40)...)
+
+	fixtures := map[float64]float64{
+		0:     10,
+		0.25:  20,
+		0.5:   30,
+		0.625: 35,
+		1:     50,
+	}
+	for q, expected := range fixtures {
+		if quantile := population.FitnessQuantile(q); quantile != expected {
+			t.Errorf("expected quantile %v to be %v; got %v", q, expected, quantile)
+		}
+	}
+
+	if median := population.MedianFitness(); median != 30 {
+		t.Errorf("expected median 30; got %v", median)
+	}
+
+	quantiles := population.FitnessQuantiles(0.25, 0.5, 0.75)
+	if len(quantiles) != 3 || quantiles[0] != 20 || quantiles[1] != 30 || quantiles[2] != 40 {
+		t.Errorf("unexpected quartiles: %v", quantiles)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected FitnessQuantile to panic for a quantile above 1")
+		}
+	}()
+	population.FitnessQuantile(1.5)
+}
@@ -0,0 +1,158 @@
+package genetic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GenerationRecord is a row of a Recorder's history, holding the GenerationStats of a generation
+// along with the diversity of the population, if measured. Durations are given in seconds.
+type GenerationRecord struct {
+	Generation       int       `json:"generation"`
+	Size             int       `json:"size"`
+	BestFitness      int       `json:"bestFitness"`
+	MeanFitness      float64   `json:"meanFitness"`
+	Diversity        *float64  `json:"diversity,omitempty"`
+	Evaluations      int       `json:"evaluations"`
+	TotalEvaluations int       `json:"totalEvaluations"`
+	EvaluationTime   float64   `json:"evaluationSeconds"`
+	Duration         float64   `json:"durationSeconds"`
+	Time             time.Time `json:"time"`
+}
+
+// Recorder is an observer which records statistics about every generation of a Population,
+// so that runs can be exported, plotted and compared. To record a population's history,
+// add the Recorder's Observe method to the population's Observers:
+//
+//	recorder := genetic.NewRecorder[MyGenome]()
+//	population.Observers = append(population.Observers, recorder.Observe)
+//
+// A Recorder is safe for concurrent use.
+type Recorder[T any] struct {
+	mutex   sync.Mutex
+	records []GenerationRecord
+
+	// Diversity optionally measures the diversity of the population after each generation,
+	// such as by sampling its DistanceDiversity. If nil, diversity is not recorded.
+	Diversity func(population *Population[T]) float64
+}
+
+// NewRecorder initializes an empty Recorder.
+func NewRecorder[T any]() *Recorder[T] {
+	return new(Recorder[T])
+}
+
+// Observe records a generation of the given population. Its signature matches ObserverFunc.
+func (recorder *Recorder[T]) Observe(population *Population[T], stats GenerationStats) {
+	var diversity *float64
+	if recorder.Diversity != nil {
+		d := recorder.Diversity(population)
+		if !math.IsNaN(d) {
+			diversity = &d
+		}
+	}
+
+	record := GenerationRecord{
+		Generation:       stats.Generation,
+		Size:             stats.Size,
+		BestFitness:      stats.BestFitness,
+		MeanFitness:      stats.MeanFitness,
+		Diversity:        diversity,
+		Evaluations:      stats.Evaluations,
+		TotalEvaluations: stats.TotalEvaluations,
+		EvaluationTime:   stats.EvaluationTime.Seconds(),
+		Duration:         stats.Duration.Seconds(),
+		Time:             stats.Time,
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.records = append(recorder.records, record)
+}
+
+// Records returns every generation recorded so far, in order.
+func (recorder *Recorder[T]) Records() []GenerationRecord {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	records := make([]GenerationRecord, len(recorder.records))
+	copy(records, recorder.records)
+	return records
+}
+
+// Reset discards every recorded generation.
+func (recorder *Recorder[T]) Reset() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.records = nil
+}
+
+// recorderCSVHeader names the columns written by WriteCSV, matching the JSON field names.
+var recorderCSVHeader = []string{
+	"generation",
+	"size",
+	"bestFitness",
+	"meanFitness",
+	"diversity",
+	"evaluations",
+	"totalEvaluations",
+	"evaluationSeconds",
+	"durationSeconds",
+	"time",
+}
+
+// WriteCSV writes the recorded generations to w as CSV, with a header row.
+// Times are formatted as RFC 3339 with nanoseconds. Diversity is left empty if not recorded.
+func (recorder *Recorder[T]) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(recorderCSVHeader); err != nil {
+		return err
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+
+	for _, record := range recorder.Records() {
+		diversity := ""
+		if record.Diversity != nil {
+			diversity = formatFloat(*record.Diversity)
+		}
+
+		row := []string{
+			strconv.Itoa(record.Generation),
+			strconv.Itoa(record.Size),
+			strconv.Itoa(record.BestFitness),
+			formatFloat(record.MeanFitness),
+			diversity,
+			strconv.Itoa(record.Evaluations),
+			strconv.Itoa(record.TotalEvaluations),
+			formatFloat(record.EvaluationTime),
+			formatFloat(record.Duration),
+			record.Time.Format(time.RFC3339Nano),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSONLines writes the recorded generations to w in JSON Lines format,
+// with one JSON object per generation.
+func (recorder *Recorder[T]) WriteJSONLines(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range recorder.Records() {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
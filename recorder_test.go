@@ -0,0 +1,66 @@
+package genetic_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestRecorder(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	recorder := genetic.NewRecorder[[]int]()
+	population.Observers = append(population.Observers, recorder.Observe)
+
+	for i := 0; i < 5; i++ {
+		population.EvolveOnce(1)
+	}
+
+	records := recorder.Records()
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records; got %d", len(records))
+	}
+	for i, record := range records {
+		if record.Generation != i+1 || record.BestFitness != 1 || record.Diversity != nil {
+			t.Errorf("unexpected record: %+v", record)
+		}
+	}
+
+	var csvOutput bytes.Buffer
+	if err := recorder.WriteCSV(&csvOutput); err != nil {
+		t.Fatalf("failed to write CSV: %s", err)
+	}
+	rows, err := csv.NewReader(&csvOutput).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %s", err)
+	}
+	if len(rows) != 6 || rows[0][0] != "generation" || rows[5][0] != "5" || rows[5][4] != "" {
+		t.Errorf("unexpected CSV output: %v", rows)
+	}
+
+	var jsonOutput bytes.Buffer
+	if err := recorder.WriteJSONLines(&jsonOutput); err != nil {
+		t.Fatalf("failed to write JSON Lines: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(jsonOutput.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 JSON lines; got %d", len(lines))
+	}
+	var decoded genetic.GenerationRecord
+	if err := json.Unmarshal([]byte(lines[4]), &decoded); err != nil {
+		t.Fatalf("failed to decode JSON line: %s", err)
+	}
+	if decoded.Generation != 5 || decoded.Evaluations != records[4].Evaluations {
+		t.Errorf("expected decoded record to match; got %+v", decoded)
+	}
+
+	recorder.Diversity = func(*genetic.Population[[]int]) float64 { return 0.5 }
+	recorder.Reset()
+	population.EvolveOnce(1)
+	if records := recorder.Records(); len(records) != 1 || records[0].Diversity == nil || *records[0].Diversity != 0.5 {
+		t.Errorf("expected a single record using the custom diversity measure; got %+v", records)
+	}
+}
@@ -0,0 +1,301 @@
+package genetic
+
+import (
+	"fmt"
+)
+
+// ReplacementPool holds the candidates competing to survive into the next generation: the
+// current generation of parents, and the children bred from them. Candidates are addressed
+// by indexes into the combined pool, where indexes below len(Parents) refer to parents, and
+// the remaining indexes refer to children, such that index len(Parents)+i refers to Children[i].
+type ReplacementPool[T any] struct {
+	// Parents are the genomes of the current generation, in descending order of fitness.
+	Parents []T
+
+	// ParentFitnesses are the fitnesses of Parents.
+	ParentFitnesses []int
+
+	// ParentAges are the number of generations, or steady-state steps, each of Parents has survived.
+	ParentAges []int
+
+	// Children are the genomes bred from the current generation. Children[2*i] and Children[2*i+1]
+	// were produced by crossover of MatingPairs[i].
+	Children []T
+
+	// ChildFitnesses are the fitnesses of Children.
+	ChildFitnesses []int
+
+	// MatingPairs are the pairs of indexes into Parents chosen by the population's SelectionFunc.
+	MatingPairs [][2]int
+}
+
+// Len returns the total number of candidates in the pool.
+func (pool *ReplacementPool[T]) Len() int {
+	return len(pool.Parents) + len(pool.Children)
+}
+
+// Genome returns the genome of the candidate at index i of the combined pool.
+func (pool *ReplacementPool[T]) Genome(i int) T {
+	if i < len(pool.Parents) {
+		return pool.Parents[i]
+	}
+	return pool.Children[i-len(pool.Parents)]
+}
+
+// Fitness returns the fitness of the candidate at index i of the combined pool.
+func (pool *ReplacementPool[T]) Fitness(i int) int {
+	if i < len(pool.ParentFitnesses) {
+		return pool.ParentFitnesses[i]
+	}
+	return pool.ChildFitnesses[i-len(pool.ParentFitnesses)]
+}
+
+// Age returns the number of generations survived by the candidate at index i of the combined
+// pool. Children always have an age of zero.
+func (pool *ReplacementPool[T]) Age(i int) int {
+	if i < len(pool.ParentAges) {
+		return pool.ParentAges[i]
+	}
+	return 0
+}
+
+// ReplacementFunc decides which candidates survive into the next generation. It is passed a pool
+// of the current parents and their children, all with computed fitnesses, and returns the
+// indexes of the survivors in the combined pool. No index may be returned more than once.
+//
+// Usually len(pool.Parents) survivors are returned, keeping the population size constant. A
+// ReplacementFunc may instead return more or fewer survivors to resize the population, so long
+// as at least PopulationSizeMinimum survive.
+//
+// A ReplacementFunc should NOT mutate the values passed to it.
+type ReplacementFunc[T any] func(pool *ReplacementPool[T]) (survivors []int)
+
+// replace replaces the current generation with the survivors chosen by the population's
+// ReplacementFunc, from a pool of the current generation followed by the given children, whose
+// fitnesses must already have been computed.
+func (population *Population[T]) replace(
+	poolGenomes []T,
+	poolFitnesses []int,
+	poolIndividuals []Individual,
+	matingPairs [][2]int,
+	replacement ReplacementFunc[T],
+) {
+	size := len(population.genomes)
+
+	parentAges := make([]int, size)
+	for i, individual := range poolIndividuals[:size] {
+		parentAges[i] = individual.Age
+	}
+
+	survivors := replacement(&ReplacementPool[T]{
+		Parents:         poolGenomes[:size],
+		ParentFitnesses: poolFitnesses[:size],
+		ParentAges:      parentAges,
+		Children:        poolGenomes[size:],
+		ChildFitnesses:  poolFitnesses[size:],
+		MatingPairs:     matingPairs,
+	})
+
+	if len(survivors) < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; ReplacementFunc returned %d survivors", PopulationSizeMinimum, len(survivors)))
+	}
+
+	nextIndividuals := permute(poolIndividuals, survivors)
+	for i, survivor := range survivors {
+		if survivor < size {
+			nextIndividuals[i].Age++
+		}
+	}
+
+	population.adopt(permute(poolGenomes, survivors), permute(poolFitnesses, survivors), nextIndividuals, len(survivors))
+}
+
+// parentIndexes returns the indexes of every parent in the pool.
+func (pool *ReplacementPool[T]) parentIndexes() []int {
+	indexes := make([]int, len(pool.Parents))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// childIndexes returns the indexes of every child in the pool.
+func (pool *ReplacementPool[T]) childIndexes() []int {
+	indexes := make([]int, len(pool.Children))
+	for i := range indexes {
+		indexes[i] = len(pool.Parents) + i
+	}
+	return indexes
+}
+
+// fittest returns the given candidate indexes sorted in descending order of fitness.
+func (pool *ReplacementPool[T]) fittest(candidates []int) []int {
+	fitnesses := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		fitnesses[i] = pool.Fitness(candidate)
+	}
+	return permute(candidates, sortedIndexes(sortDescending, fitnesses))
+}
+
+// GenerationalReplacement returns a ReplacementFunc which carries the given number of elite
+// parents over into the next generation, and fills the remainder with the fittest children.
+// Unlike a Population with no ReplacementFunc, in which the elite parents are ranked together
+// with the children and may be displaced by fitter ones, exactly elitism parents always survive.
+func GenerationalReplacement[T any](elitism int) ReplacementFunc[T] {
+	elitism = max(elitism, 0)
+
+	return func(pool *ReplacementPool[T]) []int {
+		size := len(pool.Parents)
+		if elitism > size {
+			panic("cannot carry over more elites than population size")
+		} else if len(pool.Children) < size-elitism {
+			panic("cannot use generational replacement with fewer children than non-elite places")
+		}
+
+		survivors := pool.fittest(pool.parentIndexes())[:elitism]
+		return append(survivors, pool.fittest(pool.childIndexes())[:size-elitism]...)
+	}
+}
+
+// PlusReplacement is a ReplacementFunc implementing (mu+lambda) survivor selection: parents and
+// children compete on equal terms, and the fittest len(pool.Parents) of them survive.
+// It is strongly elitist, as a parent survives for as long as it is among the fittest.
+func PlusReplacement[T any](pool *ReplacementPool[T]) []int {
+	candidates := append(pool.parentIndexes(), pool.childIndexes()...)
+	return pool.fittest(candidates)[:len(pool.Parents)]
+}
+
+// CommaReplacement is a ReplacementFunc implementing (mu,lambda) survivor selection: every parent
+// is discarded, and the fittest len(pool.Parents) children survive. It never keeps a genome for
+// more than one generation, which helps to escape local optima at the cost of sometimes losing
+// the best solution found so far.
+func CommaReplacement[T any](pool *ReplacementPool[T]) []int {
+	if len(pool.Children) < len(pool.Parents) {
+		panic("cannot use comma replacement with fewer children than parents")
+	}
+	return pool.fittest(pool.childIndexes())[:len(pool.Parents)]
+}
+
+// ReplaceWorst returns a ReplacementFunc in which the fittest count children replace the count
+// least fit parents, regardless of whether the children are fitter than them. The remaining
+// parents survive unchanged. count is capped at the number of children available.
+func ReplaceWorst[T any](count int) ReplacementFunc[T] {
+	if count < 1 {
+		panic("cannot replace fewer than 1 genome")
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		n := count
+		if n > len(pool.Children) {
+			n = len(pool.Children)
+		}
+		if n > len(pool.Parents) {
+			n = len(pool.Parents)
+		}
+
+		survivors := pool.fittest(pool.parentIndexes())[:len(pool.Parents)-n]
+		return append(survivors, pool.fittest(pool.childIndexes())[:n]...)
+	}
+}
+
+// AgeBasedReplacement returns a ReplacementFunc in which the fittest count children replace the
+// count oldest parents, regardless of fitness. Among parents of equal age, the least fit are
+// replaced first. Every genome thus survives for a limited number of generations, no matter how
+// fit it is. count is capped at the number of children available.
+func AgeBasedReplacement[T any](count int) ReplacementFunc[T] {
+	if count < 1 {
+		panic("cannot replace fewer than 1 genome")
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		n := count
+		if n > len(pool.Children) {
+			n = len(pool.Children)
+		}
+		if n > len(pool.Parents) {
+			n = len(pool.Parents)
+		}
+
+		// Sort parents by descending fitness first, so that the stable sort by age below
+		// breaks ties in favour of the fittest.
+		parents := pool.fittest(pool.parentIndexes())
+		ages := make([]int, len(parents))
+		for i, parent := range parents {
+			ages[i] = pool.Age(parent)
+		}
+		parents = permute(parents, stableSortedIndexes(sortAscending, ages))
+
+		survivors := parents[:len(parents)-n]
+		return append(survivors, pool.fittest(pool.childIndexes())[:n]...)
+	}
+}
+
+// ReplaceRandom returns a ReplacementFunc in which the fittest count children replace count
+// parents chosen uniformly at random, regardless of fitness. count is capped at the number of
+// children available.
+func ReplaceRandom[T any](count int) ReplacementFunc[T] {
+	if count < 1 {
+		panic("cannot replace fewer than 1 genome")
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		size := len(pool.Parents)
+		n := count
+		if n > len(pool.Children) {
+			n = len(pool.Children)
+		}
+		if n > size {
+			n = size
+		}
+
+		replaced := make(map[int]bool, n)
+		for _, i := range randRangeIntsUnique(size, n) {
+			replaced[i] = true
+		}
+
+		survivors := make([]int, 0, size)
+		for i := 0; i < size; i++ {
+			if !replaced[i] {
+				survivors = append(survivors, i)
+			}
+		}
+		return append(survivors, pool.fittest(pool.childIndexes())[:n]...)
+	}
+}
+
+// GenerationalGapReplacement returns a ReplacementFunc in which only a fraction of the population,
+// given by gap, is replaced each generation. That many parents, chosen uniformly at random, are
+// replaced by as many children, also chosen at random. A gap of 1.0 replaces the whole population
+// with children each generation.
+func GenerationalGapReplacement[T any](gap float64) ReplacementFunc[T] {
+	if gap <= 0 || gap > 1 {
+		panic("invalid generation gap, must be greater than 0 and at most 1")
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		size := len(pool.Parents)
+		n := int(gap*float64(size) + 0.5)
+		if n < 1 {
+			n = 1
+		}
+		if n > len(pool.Children) {
+			n = len(pool.Children)
+		}
+
+		replaced := make(map[int]bool, n)
+		for _, i := range randRangeIntsUnique(size, n) {
+			replaced[i] = true
+		}
+
+		survivors := make([]int, 0, size)
+		for i := 0; i < size; i++ {
+			if !replaced[i] {
+				survivors = append(survivors, i)
+			}
+		}
+		for _, c := range randRangeIntsUnique(len(pool.Children), n) {
+			survivors = append(survivors, size+c)
+		}
+		return survivors
+	}
+}
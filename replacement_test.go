@@ -0,0 +1,130 @@
+package genetic_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func newTestReplacementPool() *genetic.ReplacementPool[string] {
+	return &genetic.ReplacementPool[string]{
+		Parents:         []string{"p0", "p1", "p2", "p3"},
+		ParentFitnesses: []int{40, 30, 20, 10},
+		ParentAges:      []int{1, 5, 5, 0},
+		Children:        []string{"c0", "c1", "c2", "c3"},
+		ChildFitnesses:  []int{35, 5, 25, 15},
+		MatingPairs:     [][2]int{{0, 1}, {2, 3}},
+	}
+}
+
+func survivorGenomes(pool *genetic.ReplacementPool[string], survivors []int) string {
+	genomes := make([]string, len(survivors))
+	for i, survivor := range survivors {
+		genomes[i] = pool.Genome(survivor)
+	}
+	sort.Strings(genomes)
+	return fmt.Sprint(genomes)
+}
+
+func TestReplacementFuncs(t *testing.T) {
+	type Fixture struct {
+		name        string
+		replacement genetic.ReplacementFunc[string]
+		expected    string
+	}
+
+	fixtures := []*Fixture{
+		{"Generational", genetic.GenerationalReplacement[string](1), "[c0 c2 c3 p0]"},
+		{"Plus", genetic.PlusReplacement[string], "[c0 c2 p0 p1]"},
+		{"Comma", genetic.CommaReplacement[string], "[c0 c1 c2 c3]"},
+		{"ReplaceWorst", genetic.ReplaceWorst[string](2), "[c0 c2 p0 p1]"},
+		{"AgeBased", genetic.AgeBasedReplacement[string](2), "[c0 c2 p0 p3]"},
+	}
+
+	for _, fixture := range fixtures {
+		pool := newTestReplacementPool()
+		survivors := fixture.replacement(pool)
+		if genomes := survivorGenomes(pool, survivors); genomes != fixture.expected {
+			t.Errorf("%s: unexpected survivors\nWanted %s\nGot    %s", fixture.name, fixture.expected, genomes)
+		}
+	}
+}
+
+func TestGenerationalGapReplacement(t *testing.T) {
+	pool := newTestReplacementPool()
+	survivors := genetic.GenerationalGapReplacement[string](0.5)(pool)
+	if len(survivors) != len(pool.Parents) {
+		t.Fatalf("expected %d survivors; got %d", len(pool.Parents), len(survivors))
+	}
+
+	children := 0
+	for _, survivor := range survivors {
+		if survivor >= len(pool.Parents) {
+			children++
+		}
+	}
+	if children != 2 {
+		t.Errorf("expected half of the population to be replaced by children; got %d children", children)
+	}
+}
+
+func TestReplaceRandom(t *testing.T) {
+	pool := newTestReplacementPool()
+	survivors := genetic.ReplaceRandom[string](1)(pool)
+	if len(survivors) != len(pool.Parents) {
+		t.Fatalf("expected %d survivors; got %d", len(pool.Parents), len(survivors))
+	}
+	if pool.Genome(survivors[len(survivors)-1]) != "c0" {
+		t.Errorf("expected fittest child to replace a random parent; got %v", survivorGenomes(pool, survivors))
+	}
+}
+
+func TestPopulationReplacement(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Replacement = genetic.PlusReplacement[[]int]
+
+	_, previousBest := population.Best()
+	for i := 0; i < 20; i++ {
+		population.EvolveOnce(0)
+		_, best := population.Best()
+		if best < previousBest {
+			t.Fatalf("expected (mu+lambda) replacement never to lose the best genome; fitness fell from %d to %d", previousBest, best)
+		}
+		previousBest = best
+	}
+}
+
+func TestGenerationalReplacementTooFewChildren(t *testing.T) {
+	pool := newTestReplacementPool()
+	pool.Children = pool.Children[:2]
+	pool.ChildFitnesses = pool.ChildFitnesses[:2]
+	pool.MatingPairs = pool.MatingPairs[:1]
+
+	defer func() {
+		if r := recover(); r != "cannot use generational replacement with fewer children than non-elite places" {
+			t.Errorf("expected descriptive panic for too few children; got %v", r)
+		}
+	}()
+	genetic.GenerationalReplacement[string](1)(pool)
+}
+
+func TestPopulationReplacementIgnoresElitism(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Replacement = genetic.GenerationalReplacement[[]int](2)
+
+	// Elitism is ignored when the population has a ReplacementFunc, so it cannot
+	// make up for mating pairs missing from selection.
+	selection := population.Selection
+	population.Selection = func(genomes [][]int, fitnesses []int) [][2]int {
+		return selection(genomes, fitnesses)[1:]
+	}
+
+	defer func() {
+		if r := recover(); r != "too few mating pairs returned by population's SelectionFunc" {
+			t.Errorf("expected too few mating pairs to be rejected; got %v", r)
+		}
+	}()
+	population.EvolveOnce(2)
+}
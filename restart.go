@@ -0,0 +1,176 @@
+package genetic
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+)
+
+// Reasons recorded in RestartEvent.Reason.
+const (
+	RestartReasonStagnation = "stagnation"
+	RestartReasonDiversity  = "diversity"
+	RestartReasonManual     = "manual"
+)
+
+// RestartStrategy decides how a population is rebuilt when it restarts. Given the current
+// population size, it returns how many of the fittest genomes to keep, and the size of the
+// restarted population. The remainder of the restarted population is created afresh by the
+// population's GenesisFunc.
+type RestartStrategy func(size int) (keep, newSize int)
+
+// RandomRestart returns a RestartStrategy which keeps the given number of the fittest genomes,
+// and replaces the rest of the population with random genomes.
+func RandomRestart(keep int) RestartStrategy {
+	if keep < 0 {
+		panic("cannot keep fewer than 0 genomes on restart")
+	}
+
+	return func(size int) (int, int) {
+		return keep, size
+	}
+}
+
+// IPOPRestart returns a RestartStrategy which, like the IPOP restart scheme, grows the population
+// by growthFactor on every restart, allowing a larger population to explore more of the search
+// space each time the previous one stagnated. The given number of the fittest genomes are kept,
+// and the rest of the population is replaced with random genomes.
+func IPOPRestart(keep int, growthFactor float64) RestartStrategy {
+	if keep < 0 {
+		panic("cannot keep fewer than 0 genomes on restart")
+	} else if growthFactor < 1 {
+		panic("invalid IPOP growth factor, must be at least 1")
+	}
+
+	return func(size int) (int, int) {
+		return keep, int(math.Ceil(float64(size) * growthFactor))
+	}
+}
+
+// PartialRestart returns a RestartStrategy which reinitializes the given fraction of the
+// population, replacing its least fit genomes with random genomes.
+func PartialRestart(fraction float64) RestartStrategy {
+	if fraction <= 0 || fraction > 1 {
+		panic("invalid partial restart fraction, must be greater than 0 and at most 1")
+	}
+
+	return func(size int) (int, int) {
+		return size - int(math.Ceil(float64(size)*fraction)), size
+	}
+}
+
+// RestartPolicy restarts a Population automatically when its evolution stagnates. Its triggers are
+// checked after every generation evolved by EvolveOnce, and after every steady-state step of
+// StepSteadyState, EvolveSteadyState and EvolveAsync, each of which counts as a generation.
+type RestartPolicy struct {
+	// StagnationLimit triggers a restart after this many consecutive generations without any
+	// improvement in the best fitness. Zero disables this trigger.
+	StagnationLimit int
+
+	// MinDiversity triggers a restart when the population's diversity falls below it.
+	// Zero disables this trigger.
+	MinDiversity float64
+
+	// Diversity optionally measures the diversity of the population for MinDiversity, after every
+	// generation. Since RestartPolicy is not generic, Diversity is not passed the population as
+	// the diversity functions of Recorder, Metrics, Dashboard and DiversitySizing are, and instead
+	// typically closes over it, for example to sample its DistanceDiversity. If nil, the
+	// population's Diversity method is used, which compares every pair of genomes.
+	Diversity func() float64
+
+	// Strategy decides how the population is rebuilt.
+	Strategy RestartStrategy
+}
+
+// RestartEvent records a restart of a Population.
+type RestartEvent struct {
+	// Generation is the generation after which the restart occurred.
+	Generation int
+
+	// Reason is the trigger which caused the restart.
+	Reason string
+
+	// BestFitness is the best fitness in the population before the restart.
+	BestFitness int
+
+	// PreviousSize and Size are the population sizes before and after the restart.
+	PreviousSize, Size int
+}
+
+// checkRestart updates the population's stagnation counter after a generation, and restarts
+// the population if any trigger of its RestartPolicy has been reached.
+func (population *Population[T]) checkRestart() {
+	if population.fitnesses[0] > population.stagnantFitness {
+		population.stagnantFitness = population.fitnesses[0]
+		population.stagnantGenerations = 0
+	} else {
+		population.stagnantGenerations++
+	}
+
+	policy := population.RestartPolicy
+	if policy == nil {
+		return
+	}
+
+	if policy.StagnationLimit > 0 && population.stagnantGenerations >= policy.StagnationLimit {
+		population.restart(policy.Strategy, RestartReasonStagnation)
+	} else if policy.MinDiversity > 0 && population.restartDiversity(policy) < policy.MinDiversity {
+		population.restart(policy.Strategy, RestartReasonDiversity)
+	}
+}
+
+// restartDiversity measures the population's diversity using the given RestartPolicy.
+func (population *Population[T]) restartDiversity(policy *RestartPolicy) float64 {
+	if policy.Diversity != nil {
+		return policy.Diversity()
+	}
+	return population.Diversity()
+}
+
+// Restart rebuilds the population immediately using the given strategy, keeping some of the
+// fittest genomes, and creating the rest with the population's GenesisFunc. The restart is
+// recorded in the population's Restarts.
+func (population *Population[T]) Restart(strategy RestartStrategy) {
+	population.restart(strategy, RestartReasonManual)
+}
+
+func (population *Population[T]) restart(strategy RestartStrategy, reason string) {
+	if strategy == nil {
+		panic("expected to receive RestartStrategy")
+	}
+
+	previousSize := len(population.genomes)
+	keep, size := strategy(previousSize)
+	if size < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; restart strategy returned %d", PopulationSizeMinimum, size))
+	}
+
+	population.state.Lock()
+	population.restarts = append(population.restarts, RestartEvent{
+		Generation:   population.generation,
+		Reason:       reason,
+		BestFitness:  population.fitnesses[0],
+		PreviousSize: previousSize,
+		Size:         size,
+	})
+	population.state.Unlock()
+
+	population.rebuild(keep, size)
+	population.log(slog.LevelInfo, "population restarted",
+		slog.String("reason", reason),
+		slog.Int("previousSize", previousSize),
+		slog.Int("size", size),
+	)
+	population.stagnantFitness = population.fitnesses[0]
+	population.stagnantGenerations = 0
+}
+
+// Restarts returns a record of every restart of the population, in the order they occurred.
+func (population *Population[T]) Restarts() []RestartEvent {
+	population.state.RLock()
+	defer population.state.RUnlock()
+
+	restarts := make([]RestartEvent, len(population.restarts))
+	copy(restarts, population.restarts)
+	return restarts
+}
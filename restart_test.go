@@ -0,0 +1,108 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestRestartPolicyStagnation(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	population.RestartPolicy = &genetic.RestartPolicy{
+		StagnationLimit: 3,
+		Strategy:        genetic.IPOPRestart(1, 2),
+	}
+
+	for i := 0; i < 7; i++ {
+		population.EvolveOnce(1)
+	}
+
+	restarts := population.Restarts()
+	if len(restarts) != 2 {
+		t.Fatalf("expected 2 restarts after 7 stagnant generations; got %+v", restarts)
+	}
+	if restarts[0].Generation != 3 || restarts[0].Reason != genetic.RestartReasonStagnation {
+		t.Errorf("expected first restart after generation 3 due to stagnation; got %+v", restarts[0])
+	}
+	if restarts[0].Size != 20 || restarts[1].PreviousSize != 20 || restarts[1].Size != 40 {
+		t.Errorf("expected IPOP restarts to double population size; got %+v", restarts)
+	}
+	if n := len(population.Individuals()); n != 40 {
+		t.Errorf("expected population size 40; got %d", n)
+	}
+}
+
+func TestRestartPolicyDiversity(t *testing.T) {
+	population := newTestPopulation(geneFitness, genomesOf(7, 7, 7, 7, 7, 7, 7, 7, 7, 7)...)
+	population.RestartPolicy = &genetic.RestartPolicy{
+		MinDiversity: 0.1,
+		Strategy:     genetic.RandomRestart(2),
+	}
+
+	population.EvolveOnce(1)
+
+	restarts := population.Restarts()
+	if len(restarts) != 1 || restarts[0].Reason != genetic.RestartReasonDiversity {
+		t.Errorf("expected a restart due to lack of diversity; got %+v", restarts)
+	}
+}
+
+func TestRestartPolicyDiversityFunc(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+
+	// Genomes lie between 1 and 10, so their mean distance is always less than 100.
+	measurements := 0
+	population.RestartPolicy = &genetic.RestartPolicy{
+		MinDiversity: 100,
+		Diversity: func() float64 {
+			measurements++
+			return population.DistanceDiversity(lineDistance, 10)
+		},
+		Strategy: genetic.RandomRestart(2),
+	}
+
+	population.EvolveOnce(1)
+
+	if measurements != 1 {
+		t.Errorf("expected the policy's diversity function to be used once; got %d", measurements)
+	}
+	if restarts := population.Restarts(); len(restarts) != 1 || restarts[0].Reason != genetic.RestartReasonDiversity {
+		t.Errorf("expected a restart due to lack of diversity; got %+v", restarts)
+	}
+}
+
+func TestPopulationRestart(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	before := population.Individuals()
+
+	population.Restart(genetic.PartialRestart(0.3))
+
+	kept := make(map[uint64]bool)
+	for _, individual := range before[:7] {
+		kept[individual.ID] = true
+	}
+	var lastID uint64
+	for _, individual := range before {
+		if individual.ID > lastID {
+			lastID = individual.ID
+		}
+	}
+
+	after := population.Individuals()
+	survivors, newcomers := 0, 0
+	for _, individual := range after {
+		if kept[individual.ID] {
+			survivors++
+		} else if individual.ID > lastID {
+			newcomers++
+		}
+	}
+	if survivors != 7 || newcomers != 3 {
+		t.Errorf("expected 7 survivors and 3 new genomes; got %d and %d", survivors, newcomers)
+	}
+
+	restarts := population.Restarts()
+	if len(restarts) != 1 || restarts[0].Reason != genetic.RestartReasonManual {
+		t.Errorf("expected manual restart to be recorded; got %+v", restarts)
+	}
+}
@@ -16,7 +16,7 @@ func rouletteSpin(proportions []float64) int {
 // RouletteSelection spins a virtual roulette wheel to pick mating pairs.
 // Higher fitness genomes get proportionally larger sections of the roulette wheel.
 // Genomes cannot mate with themselves.
-func RouletteSelection[T any](genomes []T, fitnesses []int) [][2]T {
+func RouletteSelection[T any](genomes []T, fitnesses []int) [][2]int {
 	populationSize := len(genomes)
 	flooredFitnesses := make([]int, populationSize)
 
@@ -29,18 +29,16 @@ func RouletteSelection[T any](genomes []T, fitnesses []int) [][2]T {
 
 	wheelProportions := computeProportions(flooredFitnesses)
 
-	matingPairs := make([][2]T, 0, populationSize/2)
+	matingPairs := make([][2]int, 0, populationSize/2)
 	for len(matingPairs)*2 < populationSize {
 		mate1Index := rouletteSpin(wheelProportions)
-		mate1 := genomes[mate1Index]
 
 		var mate2Index int
 		for mate2Index != mate1Index {
 			mate2Index = rouletteSpin(wheelProportions)
 		}
-		mate2 := genomes[mate2Index]
 
-		matingPairs = append(matingPairs, [2]T{mate1, mate2})
+		matingPairs = append(matingPairs, [2]int{mate1Index, mate2Index})
 	}
 
 	return matingPairs
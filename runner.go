@@ -0,0 +1,202 @@
+package genetic
+
+import (
+	"fmt"
+	"sync"
+)
+
+// runnerProgressBuffer is the capacity of a Runner's progress channel.
+const runnerProgressBuffer = 64
+
+// Runner drives the evolution of a Population in a background goroutine, allowing it to be
+// paused, resumed, stepped and stopped from other goroutines. A Runner implements Controller,
+// so it can be controlled from a Dashboard. Every method of Runner is safe for concurrent use.
+//
+// While a Runner is running, its population must not be evolved or modified by anything else.
+type Runner[T any] struct {
+	population *Population[T]
+	elitism    int
+
+	mutex   sync.Mutex
+	wake    *sync.Cond
+	started bool
+	paused  bool
+	steps   int
+	stopped bool
+	err     error
+
+	progress chan GenerationStats
+	done     chan struct{}
+}
+
+var _ Controller = (*Runner[int])(nil)
+
+// NewRunner initializes a Runner which evolves the given population with EvolveOnce, using
+// the given elitism. The Runner does nothing until it is started.
+func NewRunner[T any](population *Population[T], elitism int) *Runner[T] {
+	if population == nil {
+		panic("expected to receive Population")
+	}
+
+	runner := &Runner[T]{
+		population: population,
+		elitism:    elitism,
+		progress:   make(chan GenerationStats, runnerProgressBuffer),
+		done:       make(chan struct{}),
+	}
+	runner.wake = sync.NewCond(&runner.mutex)
+	return runner
+}
+
+// Start begins evolving the population in a new goroutine, until either a genome is produced
+// which meets the given fitnessThreshold, maxGenerations generations have been evolved, or
+// Stop is called. Start may only be called once. A Runner which is paused before it is
+// started begins in the paused state.
+func (runner *Runner[T]) Start(fitnessThreshold, maxGenerations int) {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	if runner.started {
+		panic("cannot start a Runner more than once")
+	}
+	runner.started = true
+
+	go runner.run(fitnessThreshold, maxGenerations)
+}
+
+func (runner *Runner[T]) run(fitnessThreshold, maxGenerations int) {
+	population := runner.population
+	observers := population.Observers
+	population.Observers = append(observers[:len(observers):len(observers)], runner.observe)
+
+	defer func() {
+		if r := recover(); r != nil {
+			runner.mutex.Lock()
+			runner.err = fmt.Errorf("evolution panicked: %v", r)
+			runner.mutex.Unlock()
+		}
+
+		population.Observers = observers
+		close(runner.progress)
+		close(runner.done)
+	}()
+
+	for i := 0; i < maxGenerations; i++ {
+		if !runner.await() {
+			return
+		}
+
+		if _, bestFitness := population.Best(); bestFitness >= fitnessThreshold {
+			return
+		}
+
+		population.EvolveOnce(runner.elitism)
+	}
+}
+
+// await blocks while the runner is paused with no steps pending, and then reports
+// whether the runner should evolve another generation.
+func (runner *Runner[T]) await() bool {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	for runner.paused && runner.steps == 0 && !runner.stopped {
+		runner.wake.Wait()
+	}
+
+	if runner.stopped {
+		return false
+	}
+	if runner.paused {
+		runner.steps--
+	}
+	return true
+}
+
+// observe forwards generation statistics to the progress channel, dropping them
+// if the channel is full, so that a slow consumer never holds up evolution.
+func (runner *Runner[T]) observe(population *Population[T], stats GenerationStats) {
+	select {
+	case runner.progress <- stats:
+	default:
+	}
+}
+
+// Pause suspends evolution once the current generation is complete.
+func (runner *Runner[T]) Pause() {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	runner.paused = true
+	runner.steps = 0
+}
+
+// Resume continues a paused evolution. Any steps requested by Step are discarded.
+func (runner *Runner[T]) Resume() {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	runner.paused = false
+	runner.steps = 0
+	runner.wake.Broadcast()
+}
+
+// Step pauses evolution, if it is not already paused, and then evolves n more generations
+// before pausing again. Step does not wait for the generations to be evolved; receive from
+// Progress to follow them.
+func (runner *Runner[T]) Step(n int) {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	if !runner.paused {
+		runner.paused = true
+		runner.steps = 0
+	}
+	runner.steps += max(n, 0)
+	runner.wake.Broadcast()
+}
+
+// Stop ends evolution once the current generation is complete. Use Wait or Done to wait
+// for the runner to finish.
+func (runner *Runner[T]) Stop() {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	runner.stopped = true
+	runner.wake.Broadcast()
+}
+
+// Paused reports whether evolution is paused. A paused runner may still be evolving
+// generations requested by Step.
+func (runner *Runner[T]) Paused() bool {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	return runner.paused
+}
+
+// Progress returns a channel which receives statistics about every generation evolved by the
+// runner, and which is closed once the runner finishes. If the channel's buffer is full, newer
+// statistics are dropped rather than holding up evolution.
+func (runner *Runner[T]) Progress() <-chan GenerationStats {
+	return runner.progress
+}
+
+// Done returns a channel which is closed once the runner finishes.
+func (runner *Runner[T]) Done() <-chan struct{} {
+	return runner.done
+}
+
+// Wait blocks until the runner finishes, and then returns Err.
+func (runner *Runner[T]) Wait() error {
+	<-runner.done
+	return runner.Err()
+}
+
+// Err returns an error describing the panic which ended evolution, if any.
+func (runner *Runner[T]) Err() error {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	return runner.err
+}
@@ -0,0 +1,89 @@
+package genetic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kklash/genetic"
+)
+
+func TestRunner(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	runner := genetic.NewRunner(population, 1)
+	runner.Start(2, 20)
+
+	if err := runner.Wait(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	generations := 0
+	for stats := range runner.Progress() {
+		generations++
+		if stats.Generation != generations {
+			t.Errorf("expected progress for generation %d; got %d", generations, stats.Generation)
+		}
+	}
+	if generations != 20 || population.Generation() != 20 {
+		t.Errorf("expected runner to evolve 20 generations; got %d", generations)
+	}
+	if len(population.Observers) != 0 {
+		t.Errorf("expected runner to remove its observer once finished")
+	}
+}
+
+func TestRunnerPauseStep(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	runner := genetic.NewRunner(population, 1)
+	runner.Pause()
+	runner.Start(2, 1<<30)
+
+	select {
+	case stats := <-runner.Progress():
+		t.Fatalf("expected paused runner not to evolve; got generation %d", stats.Generation)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	runner.Step(3)
+	for i := 1; i <= 3; i++ {
+		if stats := <-runner.Progress(); stats.Generation != i {
+			t.Errorf("expected step to evolve generation %d; got %d", i, stats.Generation)
+		}
+	}
+
+	select {
+	case stats := <-runner.Progress():
+		t.Fatalf("expected runner to pause again after stepping; got generation %d", stats.Generation)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if !runner.Paused() {
+		t.Errorf("expected runner to report it is paused")
+	}
+
+	runner.Resume()
+	if stats := <-runner.Progress(); stats.Generation != 4 {
+		t.Errorf("expected resumed runner to continue from generation 4; got %d", stats.Generation)
+	}
+
+	runner.Stop()
+	select {
+	case <-runner.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected runner to stop")
+	}
+	if generation := population.Generation(); generation >= 1<<30 {
+		t.Errorf("expected runner to stop early; got generation %d", generation)
+	}
+}
+
+func TestRunnerPanic(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	population.Crossover = func(male, female []int) ([]int, []int) {
+		panic("crossover failed")
+	}
+
+	runner := genetic.NewRunner(population, 1)
+	runner.Start(2, 10)
+	if err := runner.Wait(); err == nil {
+		t.Errorf("expected runner to report the panic")
+	}
+}
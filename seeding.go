@@ -0,0 +1,38 @@
+package genetic
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Inject inserts the given genomes into the running population, replacing its least fit members.
+// The population's fitness function is then called on the whole population, with the fitnesses of
+// existing members marked as evaluated, and the population is re-sorted by fitness.
+//
+// Use Inject to introduce known good solutions, or genomes migrated from another population,
+// partway through evolution.
+func (population *Population[T]) Inject(genomes ...T) {
+	size := len(population.genomes)
+	if len(genomes) > size {
+		panic(fmt.Sprintf("cannot inject %d genomes into population of size %d", len(genomes), size))
+	}
+
+	keep := size - len(genomes)
+
+	nextGenomes := make([]T, size)
+	copy(nextGenomes, population.genomes[:keep])
+	copy(nextGenomes[keep:], genomes)
+
+	nextFitnesses := unknownFitnesses(size)
+	copy(nextFitnesses, population.fitnesses[:keep])
+
+	nextIndividuals := make([]Individual, size)
+	copy(nextIndividuals, population.individuals[:keep])
+	for i := keep; i < size; i++ {
+		nextIndividuals[i] = population.newIndividual(nil, OperatorInjection)
+	}
+
+	population.evaluate(nextGenomes, nextFitnesses, keep)
+	population.adopt(nextGenomes, nextFitnesses, nextIndividuals, size)
+	population.log(slog.LevelInfo, "genomes injected", slog.Int("count", len(genomes)))
+}
@@ -0,0 +1,58 @@
+package genetic_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestNewSeededPopulation(t *testing.T) {
+	generated := 0
+	population := genetic.NewSeededPopulation(
+		10,
+		[][]int{{500}, {400}},
+		func() []int {
+			generated++
+			return []int{generated}
+		},
+		func(male, female []int) ([]int, []int) { return []int{male[0]}, []int{female[0]} },
+		genetic.StaticFitnessFunc(func(genome []int) int { return genome[0] }),
+		genetic.TournamentSelection[[]int](2),
+		nil,
+	)
+
+	if generated != 8 {
+		t.Errorf("expected GenesisFunc to be called for the 8 unseeded slots; called %d times", generated)
+	}
+
+	best, bestFitness := population.Best()
+	if best[0] != 500 || bestFitness != 500 {
+		t.Errorf("expected seed to be the best genome; got %v with fitness %d", best, bestFitness)
+	}
+
+	individuals := population.Individuals()
+	if fmt.Sprint(individuals[0].Operators) != "[seed]" || fmt.Sprint(individuals[2].Operators) != "[genesis]" {
+		t.Errorf("expected seeded and generated genomes to be distinguished; got %v and %v",
+			individuals[0].Operators, individuals[2].Operators)
+	}
+}
+
+func TestPopulationInject(t *testing.T) {
+	population := newTestPopulation(geneFitness, countingGenomes(5)...)
+
+	population.Inject([]int{100}, []int{-100})
+
+	best, bestFitness := population.Best()
+	if best[0] != 100 || bestFitness != 100 {
+		t.Errorf("expected injected genome to be re-sorted to the front; got %v with fitness %d", best, bestFitness)
+	}
+
+	individuals := population.Individuals()
+	if last := individuals[len(individuals)-1]; fmt.Sprint(last.Operators) != "[injection]" {
+		t.Errorf("expected least fit genome to be the injected one; got %+v", last)
+	}
+	if len(individuals) != 5 {
+		t.Errorf("expected population size to remain 5; got %d", len(individuals))
+	}
+}
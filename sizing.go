@@ -0,0 +1,198 @@
+package genetic
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+)
+
+// SizingFunc decides the size of a population between generations. It is called at the end of
+// every generation evolved by EvolveOnce, and returns the size the population should have for
+// the next generation. See Population.Resize for how the population is resized.
+type SizingFunc[T any] func(population *Population[T]) (size int)
+
+// Resize grows or shrinks the population to the given size. When shrinking, the least fit genomes
+// are discarded. When growing, new genomes are created by the population's GenesisFunc and evaluated
+// by its fitness function, with the fitnesses of the existing members marked as evaluated.
+func (population *Population[T]) Resize(size int) {
+	if size < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; got %d", PopulationSizeMinimum, size))
+	}
+
+	previousSize := len(population.genomes)
+	keep := previousSize
+	if keep > size {
+		keep = size
+	}
+
+	population.rebuild(keep, size)
+	population.log(slog.LevelInfo, "population resized",
+		slog.Int("previousSize", previousSize),
+		slog.Int("size", size),
+	)
+}
+
+// rebuild replaces the population with its fittest keep genomes, followed by enough genomes
+// created by the population's GenesisFunc to reach the given size.
+func (population *Population[T]) rebuild(keep, size int) {
+	keep = max(keep, 0)
+	if keep > len(population.genomes) {
+		keep = len(population.genomes)
+	}
+	if keep > size {
+		keep = size
+	}
+
+	genomes := make([]T, size)
+	fitnesses := unknownFitnesses(size)
+	individuals := make([]Individual, size)
+
+	copy(genomes, population.genomes[:keep])
+	copy(fitnesses, population.fitnesses[:keep])
+	copy(individuals, population.individuals[:keep])
+
+	for i := keep; i < size; i++ {
+		genomes[i] = population.genesis()
+		individuals[i] = population.newIndividual(nil, OperatorGenesis)
+	}
+
+	population.evaluate(genomes, fitnesses, keep)
+	population.adopt(genomes, fitnesses, individuals, size)
+}
+
+// applySizing resizes the population according to its SizingFunc, if any.
+func (population *Population[T]) applySizing() {
+	if population.Sizing == nil {
+		return
+	}
+
+	if size := population.Sizing(population); size != len(population.genomes) {
+		population.Resize(size)
+	}
+}
+
+// DiversitySizing returns a SizingFunc which grows the population by the given factor whenever its
+// diversity falls below minDiversity, injecting random genomes to restore genetic variety. While the
+// population remains diverse, it shrinks back by the same factor, to spend fewer fitness evaluations
+// per generation as it converges. The size is always kept between minSize and maxSize.
+//
+// Diversity is measured after every generation by the given diversity function, such as one
+// sampling the population's DistanceDiversity. If diversity is nil, the population's Diversity
+// method is used, which compares every pair of genomes.
+func DiversitySizing[T any](minSize, maxSize int, minDiversity, factor float64, diversity func(*Population[T]) float64) SizingFunc[T] {
+	if minSize < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; got %d", PopulationSizeMinimum, minSize))
+	} else if maxSize < minSize {
+		panic("maximum population size cannot be less than minimum population size")
+	} else if factor <= 1 {
+		panic("invalid sizing factor, must be greater than 1")
+	}
+
+	if diversity == nil {
+		diversity = (*Population[T]).Diversity
+	}
+
+	return func(population *Population[T]) int {
+		size := len(population.genomes)
+		if diversity(population) < minDiversity {
+			size = int(math.Ceil(float64(size) * factor))
+		} else {
+			size = int(float64(size) / factor)
+		}
+
+		if size < minSize {
+			return minSize
+		} else if size > maxSize {
+			return maxSize
+		}
+		return size
+	}
+}
+
+// LifetimeReplacement returns a ReplacementFunc implementing the Genetic Algorithm with Varying
+// Population Size (GAVaPS). Rather than competing for a fixed number of places, every genome is
+// allotted a lifetime between minLifetime and maxLifetime generations by bilinear interpolation of
+// its fitness relative to the minimum, mean and maximum fitness of the pool, and parents survive
+// until their age reaches their lifetime. Each generation, the fittest reproductionRatio fraction
+// of the children are born into the population.
+//
+// The population thus grows while fit genomes are being discovered, and shrinks as it converges.
+// Unlike the original GAVaPS, lifetimes are recomputed each generation against the current pool.
+// The population never grows beyond maxSize, nor shrinks below PopulationSizeMinimum; the fittest
+// candidates are preferred in either case.
+func LifetimeReplacement[T any](minLifetime, maxLifetime int, reproductionRatio float64, maxSize int) ReplacementFunc[T] {
+	if minLifetime < 1 {
+		panic("minimum lifetime must be at least 1")
+	} else if maxLifetime < minLifetime {
+		panic("maximum lifetime cannot be less than minimum lifetime")
+	} else if reproductionRatio <= 0 || reproductionRatio > 1 {
+		panic("invalid reproduction ratio, must be greater than 0 and at most 1")
+	} else if maxSize < PopulationSizeMinimum {
+		panic(fmt.Sprintf("Population size minimum is %d; got %d", PopulationSizeMinimum, maxSize))
+	}
+
+	return func(pool *ReplacementPool[T]) []int {
+		lifetime := bilinearLifetimes(pool, float64(minLifetime), float64(maxLifetime))
+
+		survivors := make([]int, 0, pool.Len())
+		for _, parent := range pool.parentIndexes() {
+			if float64(pool.Age(parent)) < lifetime(pool.Fitness(parent)) {
+				survivors = append(survivors, parent)
+			}
+		}
+
+		births := int(math.Ceil(float64(len(pool.Parents)) * reproductionRatio))
+		if births > len(pool.Children) {
+			births = len(pool.Children)
+		}
+		children := pool.fittest(pool.childIndexes())
+		survivors = append(survivors, children[:births]...)
+
+		if len(survivors) < PopulationSizeMinimum {
+			// Revive the fittest of the remaining candidates to avoid extinction.
+			surviving := make(map[int]bool, len(survivors))
+			for _, survivor := range survivors {
+				surviving[survivor] = true
+			}
+			for _, candidate := range pool.fittest(append(pool.parentIndexes(), children[births:]...)) {
+				if len(survivors) >= PopulationSizeMinimum {
+					break
+				} else if !surviving[candidate] {
+					survivors = append(survivors, candidate)
+				}
+			}
+		}
+
+		if len(survivors) > maxSize {
+			survivors = pool.fittest(survivors)[:maxSize]
+		}
+		return survivors
+	}
+}
+
+// bilinearLifetimes returns a function allotting lifetimes to fitnesses in the given pool. Fitnesses
+// below the mean are allotted lifetimes between minLifetime and the midpoint of minLifetime and
+// maxLifetime, and fitnesses above the mean are allotted lifetimes between the midpoint and maxLifetime.
+func bilinearLifetimes[T any](pool *ReplacementPool[T], minLifetime, maxLifetime float64) func(fitness int) float64 {
+	minFitness, maxFitness := math.Inf(1), math.Inf(-1)
+	sum := 0.0
+	for i := 0; i < pool.Len(); i++ {
+		fitness := float64(pool.Fitness(i))
+		minFitness = math.Min(minFitness, fitness)
+		maxFitness = math.Max(maxFitness, fitness)
+		sum += fitness
+	}
+	mean := sum / float64(pool.Len())
+	midLifetime := (minLifetime + maxLifetime) / 2
+
+	return func(fitness int) float64 {
+		f := float64(fitness)
+		if f <= mean {
+			if mean == minFitness {
+				return midLifetime
+			}
+			return minLifetime + (midLifetime-minLifetime)*(f-minFitness)/(mean-minFitness)
+		}
+		return midLifetime + (maxLifetime-midLifetime)*(f-mean)/(maxFitness-mean)
+	}
+}
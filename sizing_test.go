@@ -0,0 +1,95 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestPopulationResize(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	population.Fitness = genetic.StaticFitnessFunc(func(genome []int) int { return genome[0] })
+	population.Restart(genetic.RandomRestart(10))
+	best, _ := population.Best()
+
+	population.Resize(15)
+	if size := len(population.Individuals()); size != 15 {
+		t.Errorf("expected population to grow to 15; got %d", size)
+	}
+
+	population.Resize(4)
+	if size := len(population.Individuals()); size != 4 {
+		t.Errorf("expected population to shrink to 4; got %d", size)
+	}
+	if genome, _ := population.Best(); genome[0] < best[0] {
+		t.Errorf("expected shrinking to keep the fittest genome; got %v, previously %v", genome, best)
+	}
+}
+
+func TestDiversitySizing(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+
+	// Diversity can never exceed 1.0, so the population always grows.
+	population.Sizing = genetic.DiversitySizing[[]int](5, 25, 1.1, 2, nil)
+	population.EvolveOnce(1)
+	if size := len(population.Individuals()); size != 20 {
+		t.Errorf("expected population to grow to 20; got %d", size)
+	}
+	population.EvolveOnce(1)
+	if size := len(population.Individuals()); size != 25 {
+		t.Errorf("expected population to grow to its maximum of 25; got %d", size)
+	}
+
+	// Diversity can never be below 0.0, so the population always shrinks.
+	population.Sizing = genetic.DiversitySizing(5, 25, 0, 2, func(population *genetic.Population[[]int]) float64 {
+		return population.DistanceDiversity(lineDistance, 10)
+	})
+	population.EvolveOnce(1)
+	population.EvolveOnce(1)
+	population.EvolveOnce(1)
+	if size := len(population.Individuals()); size != 5 {
+		t.Errorf("expected population to shrink to its minimum of 5; got %d", size)
+	}
+}
+
+func TestLifetimeReplacement(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Replacement = genetic.LifetimeReplacement[[]int](1, 4, 0.4, 100)
+
+	resized := false
+	for i := 0; i < 50; i++ {
+		population.EvolveOnce(0)
+
+		individuals := population.Individuals()
+		if len(individuals) < genetic.PopulationSizeMinimum || len(individuals) > 100 {
+			t.Fatalf("expected population size between %d and 100; got %d", genetic.PopulationSizeMinimum, len(individuals))
+		}
+		if len(individuals) != 60 {
+			resized = true
+		}
+
+		for _, individual := range individuals {
+			if individual.Age > 4 {
+				t.Fatalf("expected no individual to outlive the maximum lifetime; got %+v", individual)
+			}
+		}
+	}
+
+	if !resized {
+		t.Errorf("expected population size to vary")
+	}
+}
+
+func TestReplacementFuncTooFewSurvivors(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	population.Replacement = func(pool *genetic.ReplacementPool[[]int]) []int {
+		return []int{0}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected EvolveOnce to panic when fewer than PopulationSizeMinimum survive")
+		}
+	}()
+	population.EvolveOnce(0)
+}
@@ -0,0 +1,51 @@
+package genetic
+
+// Snapshot is a copy of the state of a Population at the end of a generation.
+type Snapshot[T any] struct {
+	// Generation is the number of generations the population had evolved.
+	Generation int
+
+	// Genomes are the members of the population, in descending order of fitness.
+	Genomes []T
+
+	// Fitnesses are the fitnesses of Genomes.
+	Fitnesses []int
+
+	// Individuals describe the life histories of Genomes.
+	Individuals []Individual
+}
+
+// Snapshot returns a consistent copy of the population's members and their fitnesses. It is
+// safe to call from any goroutine, including while the population is evolving, and the returned
+// slices and individuals may be freely modified. The genomes themselves are not deep-copied,
+// and must not be modified.
+func (population *Population[T]) Snapshot() Snapshot[T] {
+	population.state.RLock()
+	defer population.state.RUnlock()
+
+	snapshot := Snapshot[T]{
+		Generation:  population.publishedGeneration,
+		Genomes:     make([]T, len(population.genomes)),
+		Fitnesses:   make([]int, len(population.fitnesses)),
+		Individuals: cloneIndividuals(population.individuals),
+	}
+	copy(snapshot.Genomes, population.genomes)
+	copy(snapshot.Fitnesses, population.fitnesses)
+	return snapshot
+}
+
+// members returns the population's genomes and fitnesses. Since the population replaces
+// rather than modifies these slices, they may be read without holding any lock, but must
+// not be modified.
+func (population *Population[T]) members() ([]T, []int) {
+	population.state.RLock()
+	defer population.state.RUnlock()
+
+	return population.genomes, population.fitnesses
+}
+
+// nextGeneration advances the population's generation counter. The new generation is
+// not visible to readers until its members are adopted.
+func (population *Population[T]) nextGeneration() {
+	population.generation++
+}
@@ -0,0 +1,150 @@
+package genetic_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+// readConcurrently calls every read-only method of the population in a loop until done is
+// closed, checking that each snapshot is consistent. Run with -race to detect data races.
+//
+// If exactGeneration is true, each snapshot must contain the newborns of its generation.
+// This does not hold during asynchronous evolution, where other workers may breed further
+// generations before the children of an earlier one are inserted.
+func readConcurrently(t *testing.T, population *genetic.Population[[]int], done <-chan struct{}, exactGeneration bool) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			snapshot := population.Snapshot()
+			if len(snapshot.Genomes) != len(snapshot.Fitnesses) || len(snapshot.Genomes) != len(snapshot.Individuals) {
+				t.Errorf("inconsistent snapshot: %d genomes, %d fitnesses, %d individuals",
+					len(snapshot.Genomes), len(snapshot.Fitnesses), len(snapshot.Individuals))
+				return
+			}
+			if !sort.SliceIsSorted(snapshot.Fitnesses, func(i, j int) bool { return snapshot.Fitnesses[i] > snapshot.Fitnesses[j] }) {
+				t.Errorf("expected snapshot fitnesses in descending order; got %v", snapshot.Fitnesses)
+				return
+			}
+
+			// Every generation inserts newborn individuals, so the youngest member
+			// must have been born in the snapshot's generation.
+			youngest := 0
+			for _, individual := range snapshot.Individuals {
+				if individual.BirthGeneration > youngest {
+					youngest = individual.BirthGeneration
+				}
+			}
+			if youngest > snapshot.Generation || exactGeneration && youngest != snapshot.Generation {
+				t.Errorf("expected snapshot of generation %d to contain its newborns; youngest born in %d", snapshot.Generation, youngest)
+				return
+			}
+
+			population.Best()
+			population.Generation()
+			population.Individuals()
+			population.Restarts()
+			population.Evaluations()
+			population.Diversity()
+			population.DistanceDiversity(lineDistance, 10)
+			population.Niches(lineDistance, 5)
+			population.Len()
+			population.TopN(5)
+			population.MedianFitness()
+			population.All()(func([]int, int) bool { return true })
+		}
+	}()
+	return &wg
+}
+
+func TestPopulationConcurrentReads(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.RestartPolicy = &genetic.RestartPolicy{
+		StagnationLimit: 5,
+		Strategy:        genetic.PartialRestart(0.5),
+	}
+	population.Sizing = genetic.DiversitySizing[[]int](20, 80, 0.5, 1.5, nil)
+
+	done := make(chan struct{})
+	wg := readConcurrently(t, population, done, true)
+
+	for i := 0; i < 100; i++ {
+		population.EvolveOnce(2)
+	}
+	for i := 0; i < 100; i++ {
+		population.StepSteadyState()
+	}
+	population.Inject([]int{50})
+	population.Resize(30)
+
+	close(done)
+	wg.Wait()
+}
+
+func TestPopulationConcurrentReadsAsync(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+
+	done := make(chan struct{})
+	wg := readConcurrently(t, population, done, false)
+
+	population.EvolveAsync(context.Background(), 4, 2000, 2000)
+
+	close(done)
+	wg.Wait()
+}
+
+func TestPopulationConcurrentReadsRunner(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	dashboard := genetic.NewDashboard[[]int](nil)
+	population.Observers = append(population.Observers, dashboard.Observe)
+
+	runner := genetic.NewRunner(population, 2)
+	dashboard.Controller = runner
+
+	done := make(chan struct{})
+	wg := readConcurrently(t, population, done, true)
+
+	runner.Start(2000, 200)
+	runner.Pause()
+	dashboard.Status()
+	runner.Step(5)
+	runner.Resume()
+	dashboard.History()
+	if err := runner.Wait(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+func TestSnapshotIsCopy(t *testing.T) {
+	population := newTestPopulation(constantFitness, countingGenomes(10)...)
+	population.EvolveOnce(1)
+	snapshot := population.Snapshot()
+	snapshot.Fitnesses[0] = 1000
+	snapshot.Individuals[len(snapshot.Individuals)-1].Operators[0] = "modified"
+
+	if _, bestFitness := population.Best(); bestFitness == 1000 {
+		t.Errorf("expected modifying a snapshot not to affect the population")
+	}
+	for _, individual := range population.Individuals() {
+		if individual.Operators[0] == "modified" {
+			t.Errorf("expected modifying a snapshot's individuals not to affect the population")
+		}
+	}
+	if snapshot.Generation != 1 || len(snapshot.Genomes) != 10 {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
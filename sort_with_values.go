@@ -47,3 +47,42 @@ func sortWithValues[T any](order sortOrder, items []T, values []int) {
 		Values: values,
 	})
 }
+
+// sortedIndexes returns the indexes of the given values, sorted in ascending or
+// descending order, as specified by order, of the values they refer to.
+func sortedIndexes(order sortOrder, values []int) []int {
+	indexes := make([]int, len(values))
+	sortedValues := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	copy(sortedValues, values)
+	sortWithValues(order, indexes, sortedValues)
+	return indexes
+}
+
+// stableSortedIndexes is like sortedIndexes, except that indexes of equal
+// values are kept in their original relative order.
+func stableSortedIndexes(order sortOrder, values []int) []int {
+	indexes := make([]int, len(values))
+	sortedValues := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	copy(sortedValues, values)
+	sort.Stable(&valuedSlice[int]{
+		Order:  order,
+		Items:  indexes,
+		Values: sortedValues,
+	})
+	return indexes
+}
+
+// permute returns a new slice whose i-th element is items[indexes[i]].
+func permute[T any](items []T, indexes []int) []T {
+	permuted := make([]T, len(indexes))
+	for i, index := range indexes {
+		permuted[i] = items[index]
+	}
+	return permuted
+}
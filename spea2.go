@@ -0,0 +1,181 @@
+package genetic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SPEA2Archive is a bounded external archive of non-dominated genomes, maintained using the
+// environmental selection of the Strength Pareto Evolutionary Algorithm 2 (SPEA2). The archive
+// size is fixed independently of the size of any population feeding it.
+//
+// When more non-dominated genomes are found than fit in the archive, the archive is truncated by
+// repeatedly removing the member closest to its nearest neighbours in objective space, which
+// preserves the spread of the front. When fewer are known, the archive is filled with the
+// best dominated genomes, as ranked by SPEA2 strength fitness.
+type SPEA2Archive[T any] struct {
+	capacity   int
+	genomes    []T
+	objectives [][]float64
+}
+
+// NewSPEA2Archive creates an empty SPEA2Archive which holds at most capacity genomes.
+func NewSPEA2Archive[T any](capacity int) *SPEA2Archive[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("invalid SPEA2 archive capacity: %d", capacity))
+	}
+
+	return &SPEA2Archive[T]{capacity: capacity}
+}
+
+// Len returns the number of genomes in the archive.
+func (archive *SPEA2Archive[T]) Len() int {
+	return len(archive.genomes)
+}
+
+// Members returns the genomes in the archive along with their objective scores.
+func (archive *SPEA2Archive[T]) Members() ([]T, [][]float64) {
+	genomes := make([]T, len(archive.genomes))
+	objectives := make([][]float64, len(archive.objectives))
+	copy(genomes, archive.genomes)
+	copy(objectives, archive.objectives)
+	return genomes, objectives
+}
+
+// Update merges the given genomes and their objective scores into the archive, and then
+// reduces the combined set back down to the archive's capacity.
+func (archive *SPEA2Archive[T]) Update(genomes []T, objectives [][]float64) {
+	if len(genomes) != len(objectives) {
+		panic("cannot update SPEA2 archive with mismatching genomes and objectives")
+	}
+
+	allGenomes := append(append([]T{}, archive.genomes...), genomes...)
+	allObjectives := append(append([][]float64{}, archive.objectives...), objectives...)
+
+	fitnesses := spea2Fitnesses(allObjectives)
+
+	var survivors []int
+	for i, fitness := range fitnesses {
+		if fitness < 1 {
+			// Non-dominated members have no raw fitness, only a density below 1.
+			survivors = append(survivors, i)
+		}
+	}
+
+	if len(survivors) < archive.capacity {
+		var dominated []int
+		for i, fitness := range fitnesses {
+			if fitness >= 1 {
+				dominated = append(dominated, i)
+			}
+		}
+		sort.SliceStable(dominated, func(a, b int) bool {
+			return fitnesses[dominated[a]] < fitnesses[dominated[b]]
+		})
+
+		fill := archive.capacity - len(survivors)
+		if fill > len(dominated) {
+			fill = len(dominated)
+		}
+		survivors = append(survivors, dominated[:fill]...)
+	} else if len(survivors) > archive.capacity {
+		survivors = truncateByDensity(allObjectives, survivors, archive.capacity)
+	}
+
+	archive.genomes = make([]T, len(survivors))
+	archive.objectives = make([][]float64, len(survivors))
+	for i, s := range survivors {
+		archive.genomes[i] = allGenomes[s]
+		archive.objectives[i] = allObjectives[s]
+	}
+}
+
+// spea2Fitnesses computes the SPEA2 fitness of every objective vector, where lower is better.
+// The fitness is the sum of the strengths of every vector dominating it (the raw fitness), plus a
+// density term in (0, 0.5] based on the distance to its k-th nearest neighbour.
+func spea2Fitnesses(objectives [][]float64) []float64 {
+	n := len(objectives)
+
+	strengths := make([]int, n)
+	for i := range objectives {
+		for j := range objectives {
+			if Dominates(objectives[i], objectives[j]) {
+				strengths[i]++
+			}
+		}
+	}
+
+	k := int(math.Sqrt(float64(n)))
+	if k >= n {
+		k = n - 1
+	}
+
+	fitnesses := make([]float64, n)
+	distances := make([]float64, 0, n)
+	for i := range objectives {
+		raw := 0
+		distances = distances[:0]
+		for j := range objectives {
+			if i == j {
+				continue
+			}
+			if Dominates(objectives[j], objectives[i]) {
+				raw += strengths[j]
+			}
+			distances = append(distances, EuclideanDistance(objectives[i], objectives[j]))
+		}
+
+		density := 0.0
+		if len(distances) > 0 {
+			sort.Float64s(distances)
+			density = 1 / (distances[k-1] + 2)
+		} else {
+			density = 0.5
+		}
+
+		fitnesses[i] = float64(raw) + density
+	}
+
+	return fitnesses
+}
+
+// truncateByDensity iteratively removes the member of candidates which is closest to its
+// neighbours, until only capacity members remain. Ties in distance to the nearest neighbour are
+// broken by the distance to the second nearest neighbour, and so on.
+func truncateByDensity(objectives [][]float64, candidates []int, capacity int) []int {
+	remaining := make([]int, len(candidates))
+	copy(remaining, candidates)
+
+	for len(remaining) > capacity {
+		neighbourDistances := make([][]float64, len(remaining))
+		for a, i := range remaining {
+			for b, j := range remaining {
+				if a != b {
+					neighbourDistances[a] = append(neighbourDistances[a], EuclideanDistance(objectives[i], objectives[j]))
+				}
+			}
+			sort.Float64s(neighbourDistances[a])
+		}
+
+		mostCrowded := 0
+		for a := 1; a < len(remaining); a++ {
+			if lexicographicallyLess(neighbourDistances[a], neighbourDistances[mostCrowded]) {
+				mostCrowded = a
+			}
+		}
+
+		remaining = append(remaining[:mostCrowded], remaining[mostCrowded+1:]...)
+	}
+
+	return remaining
+}
+
+func lexicographicallyLess(a, b []float64) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,78 @@
+package genetic_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestSPEA2Archive(t *testing.T) {
+	archive := genetic.NewSPEA2Archive[int](3)
+
+	// Five non-dominated points along a line, plus one dominated point.
+	genomes := []int{0, 1, 2, 3, 4, 5}
+	objectives := [][]float64{
+		{0, 4},
+		{1, 3},
+		{1.1, 2.9},
+		{3, 1},
+		{4, 0},
+		{0, 0},
+	}
+	archive.Update(genomes, objectives)
+
+	if archive.Len() != 3 {
+		t.Fatalf("expected archive to be truncated to capacity 3; got %d", archive.Len())
+	}
+
+	members, _ := archive.Members()
+	kept := make(map[int]bool)
+	for _, genome := range members {
+		kept[genome] = true
+	}
+	if !kept[0] || !kept[4] {
+		t.Errorf("expected extreme points of the front to survive truncation; got %v", members)
+	}
+	if kept[5] {
+		t.Errorf("expected dominated genome to be excluded; got %v", members)
+	}
+	if kept[1] && kept[2] {
+		t.Errorf("expected one of the two most crowded genomes to be truncated; got %v", members)
+	}
+
+	// When too few non-dominated genomes exist, dominated ones fill the archive.
+	sparse := genetic.NewSPEA2Archive[int](2)
+	sparse.Update([]int{0, 1, 2}, [][]float64{{2, 2}, {1, 1}, {0, 0}})
+	members, _ = sparse.Members()
+	if len(members) != 2 || members[0] != 0 || members[1] != 1 {
+		t.Errorf("expected archive to be filled with best dominated genome; got %v", members)
+	}
+}
+
+func TestMultiObjectivePopulationArchive(t *testing.T) {
+	population := genetic.NewMultiObjectivePopulation(
+		20,
+		func() []float64 { return []float64{float64(rand.Intn(100)) / 10} },
+		func(male, female []float64) ([]float64, []float64) {
+			return []float64{male[0]}, []float64{female[0]}
+		},
+		genetic.StaticMultiObjectiveFitnessFunc(func(genome []float64) []float64 {
+			x := genome[0]
+			return []float64{-x * x, -(x - 2) * (x - 2)}
+		}),
+		func(genome []float64) { genome[0] += float64(rand.Intn(3)-1) / 10 },
+	)
+	population.Archive = genetic.NewSPEA2Archive[[]float64](5)
+	population.Evolve(10)
+
+	if population.Archive.Len() != 5 {
+		t.Fatalf("expected archive to be full; got %d members", population.Archive.Len())
+	}
+
+	_, objectives := population.Archive.Members()
+	if volume := genetic.Hypervolume(objectives, []float64{-100, -100}); volume <= 0 || math.IsNaN(volume) {
+		t.Errorf("expected positive hypervolume of archive; got %f", volume)
+	}
+}
@@ -0,0 +1,80 @@
+package genetic
+
+// StepSteadyState performs a single step of steady-state evolution. Rather than rebuilding the
+// whole population as EvolveOnce does, a single pair of mates is chosen, and the two children
+// they produce are evaluated and inserted into the population, displacing existing members.
+//
+// Mates are chosen by the population's PairSelectionFunc, or if it has none, are the first pair
+// returned by the population's SelectionFunc. The fitness function is
+// called only on the two children, so it should not depend on the rest of the population.
+// The children are inserted by the population's ReplacementFunc, which must tolerate a pool with
+// only two children. If the population has no ReplacementFunc, ReplaceWorst(2) is used, in which
+// the children replace the two least fit members of the population. If the population has a
+// RestartPolicy whose trigger has been reached by the end of the step, the population is restarted.
+func (population *Population[T]) StepSteadyState() {
+	defer population.logPanic()
+
+	childGenomes, childIndividuals, matingPair := population.breedSteadyState()
+
+	childFitnesses := unknownFitnesses(len(childGenomes))
+	population.evaluate(childGenomes, childFitnesses, 0)
+	population.creditOperators(childIndividuals, childFitnesses)
+
+	population.insertSteadyState(childGenomes, childFitnesses, childIndividuals, [][2]int{matingPair})
+	population.checkRestart()
+	population.notifyObservers()
+}
+
+// breedSteadyState selects a single pair of mates and returns the two children they produce.
+// Each steady-state step counts as a generation.
+func (population *Population[T]) breedSteadyState() ([]T, []Individual, [2]int) {
+	var matingPair [2]int
+	if population.PairSelection != nil {
+		matingPair = population.PairSelection(population.genomes, population.fitnesses)
+	} else {
+		matingPairs := population.Selection(population.genomes, population.fitnesses)
+		if len(matingPairs) == 0 {
+			panic("too few mating pairs returned by population's SelectionFunc")
+		}
+		matingPair = matingPairs[0]
+	}
+
+	population.nextGeneration()
+	childGenomes, childIndividuals := population.breed([][2]int{matingPair})
+	return childGenomes, childIndividuals, matingPair
+}
+
+// insertSteadyState inserts evaluated children into the population using the population's
+// ReplacementFunc, or ReplaceWorst if it has none.
+func (population *Population[T]) insertSteadyState(
+	childGenomes []T,
+	childFitnesses []int,
+	childIndividuals []Individual,
+	matingPairs [][2]int,
+) {
+	replacement := population.Replacement
+	if replacement == nil {
+		replacement = ReplaceWorst[T](len(childGenomes))
+	}
+
+	size := len(population.genomes)
+	poolGenomes := append(append(make([]T, 0, size+len(childGenomes)), population.genomes...), childGenomes...)
+	poolFitnesses := append(append(make([]int, 0, size+len(childGenomes)), population.fitnesses...), childFitnesses...)
+	poolIndividuals := append(append(make([]Individual, 0, size+len(childGenomes)), population.individuals...), childIndividuals...)
+
+	population.replace(poolGenomes, poolFitnesses, poolIndividuals, matingPairs, replacement)
+}
+
+// EvolveSteadyState evolves the population in steady-state mode until either a genome is produced
+// which meets the given fitnessThreshold, or maxSteps steps have been performed. Each step evaluates
+// only two new genomes. See StepSteadyState for details.
+func (population *Population[T]) EvolveSteadyState(fitnessThreshold, maxSteps int) {
+	for i := 0; i < maxSteps; i++ {
+		_, bestFitness := population.Best()
+		if bestFitness >= fitnessThreshold {
+			break
+		}
+
+		population.StepSteadyState()
+	}
+}
@@ -0,0 +1,69 @@
+package genetic_test
+
+import (
+	"testing"
+
+	"github.com/kklash/genetic"
+)
+
+func TestEvolveSteadyState(t *testing.T) {
+	evaluations := 0
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(func(genome []int) int {
+		evaluations++
+		return twinPeaksFitness(genome)
+	}))
+	evaluations = 0
+
+	steps := 300
+	population.EvolveSteadyState(1001, steps)
+
+	if evaluations != steps*2 {
+		t.Errorf("expected exactly 2 fitness evaluations per step; got %d over %d steps", evaluations, steps)
+	}
+
+	_, bestFitness := population.Best()
+	if bestFitness < 990 {
+		t.Errorf("expected steady-state evolution to approach an optimum; best fitness %d", bestFitness)
+	}
+}
+
+func TestStepSteadyStatePairSelection(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(twinPeaksFitness))
+	population.Selection = func([][]int, []int) [][2]int {
+		t.Fatal("expected SelectionFunc not to be called when PairSelection is set")
+		return nil
+	}
+
+	pairSelections := 0
+	tournament := genetic.TournamentPairSelection[[]int](3)
+	population.PairSelection = func(genomes [][]int, fitnesses []int) [2]int {
+		pairSelections++
+		matingPair := tournament(genomes, fitnesses)
+		if matingPair[0] == matingPair[1] {
+			t.Fatalf("genome %d mated with itself", matingPair[0])
+		}
+		return matingPair
+	}
+
+	steps := 50
+	population.EvolveSteadyState(1001, steps)
+
+	if pairSelections != steps {
+		t.Errorf("expected one pair selection per step; got %d over %d steps", pairSelections, steps)
+	}
+}
+
+func TestStepSteadyStateRestartPolicy(t *testing.T) {
+	population := newTwinPeaksPopulation(genetic.StaticFitnessFunc(func([]int) int { return 0 }))
+	population.RestartPolicy = &genetic.RestartPolicy{
+		StagnationLimit: 5,
+		Strategy:        genetic.RandomRestart(2),
+	}
+
+	population.EvolveSteadyState(1, 5)
+
+	restarts := population.Restarts()
+	if len(restarts) != 1 || restarts[0].Reason != genetic.RestartReasonStagnation {
+		t.Errorf("expected a stagnation restart after 5 steady-state steps; got %+v", restarts)
+	}
+}
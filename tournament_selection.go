@@ -13,6 +13,17 @@ func randomTournamentWinner(poolSize int, fitnesses []int) int {
 	return bestContestant
 }
 
+// randomTournamentPair runs tournaments until two distinct winners are found.
+func randomTournamentPair(poolSize int, fitnesses []int) [2]int {
+	for {
+		mate1Index := randomTournamentWinner(poolSize, fitnesses)
+		mate2Index := randomTournamentWinner(poolSize, fitnesses)
+		if mate1Index != mate2Index {
+			return [2]int{mate1Index, mate2Index}
+		}
+	}
+}
+
 // TournamentSelection returns a SelectionFunc of T which selects mates by
 // randomly creating 'tournaments' between poolSize contestants. The fittest
 // contestants from each random tournament are selected to mate.
@@ -22,29 +33,35 @@ func TournamentSelection[T any](poolSize int) SelectionFunc[T] {
 		panic("cannot use tournament selection with pool size less than 2")
 	}
 
-	return func(population []T, fitnesses []int) [][2]T {
+	return func(population []T, fitnesses []int) [][2]int {
 		populationSize := len(population)
 
 		if poolSize > populationSize {
 			panic("cannot select from tournament pool greater than population size")
 		}
 
-		matingPairs := make([][2]T, 0, populationSize/2)
+		matingPairs := make([][2]int, 0, populationSize/2)
 
 		for len(matingPairs)*2 < populationSize {
-			var matingPairIndexes [2]int
-			for i := 0; i < len(matingPairIndexes); i++ {
-				matingPairIndexes[i] = randomTournamentWinner(poolSize, fitnesses)
-			}
-
-			if matingPairIndexes[0] != matingPairIndexes[1] {
-				matingPairs = append(matingPairs, [2]T{
-					population[matingPairIndexes[0]],
-					population[matingPairIndexes[1]],
-				})
-			}
+			matingPairs = append(matingPairs, randomTournamentPair(poolSize, fitnesses))
 		}
 
 		return matingPairs
 	}
 }
+
+// TournamentPairSelection returns a PairSelectionFunc of T which selects a single pair
+// of mates as TournamentSelection does, running only the two tournaments needed.
+// Genomes cannot mate with themselves.
+func TournamentPairSelection[T any](poolSize int) PairSelectionFunc[T] {
+	if poolSize < 2 {
+		panic("cannot use tournament selection with pool size less than 2")
+	}
+
+	return func(population []T, fitnesses []int) [2]int {
+		if poolSize > len(population) {
+			panic("cannot select from tournament pool greater than population size")
+		}
+		return randomTournamentPair(poolSize, fitnesses)
+	}
+}